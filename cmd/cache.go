@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/cache"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Purge and configure the cache of a zone",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Purge cached content of a zone",
+	RunE:  cachePurge,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cachePurgeCmd)
+
+	cachePurgeCmd.Flags().String("zone", "", "zone name")
+	cachePurgeCmd.Flags().Bool("everything", false, "purge all cached content of the zone")
+	cachePurgeCmd.Flags().StringArray("url", nil, "URL to purge (repeatable)")
+	cachePurgeCmd.Flags().String("url-file", "", "file with one URL to purge per line, - for stdin")
+	cachePurgeCmd.Flags().StringArray("tag", nil, "cache tag to purge (repeatable)")
+	cachePurgeCmd.Flags().StringArray("host", nil, "hostname to purge (repeatable)")
+	cachePurgeCmd.Flags().StringArray("prefix", nil, "URL prefix to purge, without scheme (repeatable)")
+	cachePurgeCmd.Flags().Int("chunk-size", 30, "items per purge request")
+	cachePurgeCmd.Flags().Int("concurrency", 4, "purge requests to run at the same time")
+}
+
+// purgeChunk is one purge request and its outcome.
+type purgeChunk struct {
+	Kind  string   `json:"kind"`
+	Items []string `json:"items"`
+	ID    string   `json:"id,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+func (p purgeChunk) body() cache.CachePurgeParamsBodyUnion {
+	switch p.Kind {
+	case "tags":
+		return cache.CachePurgeParamsBodyCachePurgeFlexPurgeByTags{Tags: cloudflare.F(p.Items)}
+	case "hosts":
+		return cache.CachePurgeParamsBodyCachePurgeFlexPurgeByHostnames{Hosts: cloudflare.F(p.Items)}
+	case "prefixes":
+		return cache.CachePurgeParamsBodyCachePurgeFlexPurgeByPrefixes{Prefixes: cloudflare.F(p.Items)}
+	}
+	return cache.CachePurgeParamsBodyCachePurgeSingleFile{Files: cloudflare.F(p.Items)}
+}
+
+// chunkPurge splits items into purge requests of at most size items.
+func chunkPurge(kind string, items []string, size int) []purgeChunk {
+	var chunks []purgeChunk
+	for len(items) > 0 {
+		n := size
+		if len(items) < n {
+			n = len(items)
+		}
+		chunks = append(chunks, purgeChunk{Kind: kind, Items: items[:n]})
+		items = items[n:]
+	}
+	return chunks
+}
+
+// getPurgeURLs collects the --url flags and the lines of --url-file.
+func getPurgeURLs(c *cobra.Command) ([]string, error) {
+	urls, _ := c.Flags().GetStringArray("url")
+	file, _ := c.Flags().GetString("url-file")
+	if file == "" {
+		return urls, nil
+	}
+
+	f := os.Stdin
+	if file != "-" {
+		var err error
+		if f, err = os.Open(file); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+	}
+	lines, err := readLines(f)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %s: %w", file, err)
+	}
+	return append(urls, lines...), nil
+}
+
+func cachePurge(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	everything, _ := c.Flags().GetBool("everything")
+	tags, _ := c.Flags().GetStringArray("tag")
+	hosts, _ := c.Flags().GetStringArray("host")
+	prefixes, _ := c.Flags().GetStringArray("prefix")
+	chunkSize, _ := c.Flags().GetInt("chunk-size")
+	concurrency, _ := c.Flags().GetInt("concurrency")
+
+	if chunkSize < 1 || concurrency < 1 {
+		return fmt.Errorf("chunk-size and concurrency must be at least 1")
+	}
+
+	urls, err := getPurgeURLs(c)
+	if err != nil {
+		return err
+	}
+
+	var chunks []purgeChunk
+	chunks = append(chunks, chunkPurge("files", urls, chunkSize)...)
+	chunks = append(chunks, chunkPurge("tags", tags, chunkSize)...)
+	chunks = append(chunks, chunkPurge("hosts", hosts, chunkSize)...)
+	chunks = append(chunks, chunkPurge("prefixes", prefixes, chunkSize)...)
+
+	if everything && len(chunks) > 0 {
+		return fmt.Errorf("--everything cannot be combined with --url, --tag, --host or --prefix")
+	}
+	if !everything && len(chunks) == 0 {
+		return fmt.Errorf("nothing to purge: use --everything, --url, --url-file, --tag, --host or --prefix")
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	if everything {
+		res, err := client.Cache.Purge(c.Context(), cache.CachePurgeParams{
+			ZoneID: cloudflare.F(zoneID),
+			Body: cache.CachePurgeParamsBodyCachePurgeEverything{
+				PurgeEverything: cloudflare.F(true),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error purging cache: %w", err)
+		}
+		fmt.Printf("Purged everything from %s (%s)\n", zoneName, res.ID)
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(p *purgeChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := client.Cache.Purge(c.Context(), cache.CachePurgeParams{
+				ZoneID: cloudflare.F(zoneID),
+				Body:   p.body(),
+			})
+			if err != nil {
+				p.Error = err.Error()
+				return
+			}
+			p.ID = res.ID
+		}(&chunks[i])
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, p := range chunks {
+		if p.Error != "" {
+			failed++
+		}
+	}
+
+	if jsonOutput(c) {
+		if err := writeJSON(chunks); err != nil {
+			return err
+		}
+	} else {
+		output := make([][]string, 0, len(chunks))
+		for i, p := range chunks {
+			result := p.ID
+			if p.Error != "" {
+				result = p.Error
+			}
+			output = append(output, []string{strconv.Itoa(i + 1), p.Kind, strconv.Itoa(len(p.Items)), result})
+		}
+		writeTable(output, "Chunk", "Type", "Items", "Result")
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d purge requests failed", failed, len(chunks))
+	}
+	return nil
+}
@@ -0,0 +1,437 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/argo"
+	"github.com/cloudflare/cloudflare-go/v6/cache"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+// cacheToggle is a zone cache setting that is either on or off. Each one is
+// exposed as a "get" and "set" command pair.
+type cacheToggle struct {
+	use   string
+	short string
+	get   func(c *cobra.Command, zoneID string) (string, error)
+	set   func(c *cobra.Command, zoneID, value string) (string, error)
+}
+
+var cacheToggles = []cacheToggle{
+	{
+		use:   "tiered",
+		short: "Tiered cache (Argo)",
+		get: func(c *cobra.Command, zoneID string) (string, error) {
+			res, err := client.Argo.TieredCaching.Get(c.Context(), argo.TieredCachingGetParams{ZoneID: cloudflare.F(zoneID)})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+		set: func(c *cobra.Command, zoneID, value string) (string, error) {
+			res, err := client.Argo.TieredCaching.Edit(c.Context(), argo.TieredCachingEditParams{
+				ZoneID: cloudflare.F(zoneID),
+				Value:  cloudflare.F(argo.TieredCachingEditParamsValue(value)),
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+	},
+	{
+		use:   "smart-tiered",
+		short: "Smart tiered cache topology",
+		get: func(c *cobra.Command, zoneID string) (string, error) {
+			res, err := client.Cache.SmartTieredCache.Get(c.Context(), cache.SmartTieredCacheGetParams{ZoneID: cloudflare.F(zoneID)})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+		set: func(c *cobra.Command, zoneID, value string) (string, error) {
+			res, err := client.Cache.SmartTieredCache.Edit(c.Context(), cache.SmartTieredCacheEditParams{
+				ZoneID: cloudflare.F(zoneID),
+				Value:  cloudflare.F(cache.SmartTieredCacheEditParamsValue(value)),
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+	},
+	{
+		use:   "regional-tiered",
+		short: "Regional tiered cache",
+		get: func(c *cobra.Command, zoneID string) (string, error) {
+			res, err := client.Cache.RegionalTieredCache.Get(c.Context(), cache.RegionalTieredCacheGetParams{ZoneID: cloudflare.F(zoneID)})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+		set: func(c *cobra.Command, zoneID, value string) (string, error) {
+			res, err := client.Cache.RegionalTieredCache.Edit(c.Context(), cache.RegionalTieredCacheEditParams{
+				ZoneID: cloudflare.F(zoneID),
+				Value:  cloudflare.F(cache.RegionalTieredCacheEditParamsValue(value)),
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+	},
+	{
+		use:   "reserve",
+		short: "Cache Reserve",
+		get: func(c *cobra.Command, zoneID string) (string, error) {
+			res, err := client.Cache.CacheReserve.Get(c.Context(), cache.CacheReserveGetParams{ZoneID: cloudflare.F(zoneID)})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+		set: func(c *cobra.Command, zoneID, value string) (string, error) {
+			res, err := client.Cache.CacheReserve.Edit(c.Context(), cache.CacheReserveEditParams{
+				ZoneID: cloudflare.F(zoneID),
+				Value:  cloudflare.F(cache.CacheReserveEditParamsValue(value)),
+			})
+			if err != nil {
+				return "", err
+			}
+			return res.JSON.RawJSON(), nil
+		},
+	},
+}
+
+var cacheReserveStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of the latest Cache Reserve clear operation",
+	RunE:  cacheReserveStatus,
+}
+
+var cacheReserveClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all data from Cache Reserve; Cache Reserve must be off",
+	RunE:  cacheReserveClear,
+}
+
+var cacheVariantsCmd = &cobra.Command{
+	Use:   "variants",
+	Short: "Image variants served from cache by file extension",
+}
+
+var cacheVariantsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the variants of a zone",
+	RunE:  cacheVariantsGet,
+}
+
+var cacheVariantsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Replace the variants of a zone",
+	RunE:  cacheVariantsSet,
+}
+
+var cacheVariantsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Remove the variants of a zone",
+	RunE:  cacheVariantsDelete,
+}
+
+func init() {
+	for _, t := range cacheToggles {
+		cmd := newCacheToggleCmd(t)
+		if t.use == "reserve" {
+			cmd.AddCommand(cacheReserveStatusCmd)
+			cmd.AddCommand(cacheReserveClearCmd)
+		}
+		cacheCmd.AddCommand(cmd)
+	}
+	cacheReserveStatusCmd.Flags().String("zone", "", "zone name")
+	cacheReserveClearCmd.Flags().String("zone", "", "zone name")
+
+	cacheCmd.AddCommand(cacheVariantsCmd)
+	cacheVariantsCmd.AddCommand(cacheVariantsGetCmd)
+	cacheVariantsCmd.AddCommand(cacheVariantsSetCmd)
+	cacheVariantsCmd.AddCommand(cacheVariantsDeleteCmd)
+
+	cacheVariantsGetCmd.Flags().String("zone", "", "zone name")
+	cacheVariantsSetCmd.Flags().String("zone", "", "zone name")
+	cacheVariantsSetCmd.Flags().StringArray("variant", nil, "extension and MIME types to serve for it, e.g. jpeg=image/webp,image/avif (repeatable)")
+	cacheVariantsDeleteCmd.Flags().String("zone", "", "zone name")
+}
+
+func newCacheToggleCmd(t cacheToggle) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   t.use,
+		Short: t.short,
+	}
+	getCmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show whether " + t.short + " is on",
+		RunE: func(c *cobra.Command, args []string) error {
+			return cacheToggleRun(c, args, t, "")
+		},
+	}
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Turn " + t.short + " on or off",
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := checkFlags(c, "value"); err != nil {
+				return err
+			}
+			value, _ := c.Flags().GetString("value")
+			if value != "on" && value != "off" {
+				return fmt.Errorf("invalid value %q: must be on or off", value)
+			}
+			return cacheToggleRun(c, args, t, value)
+		},
+	}
+	getCmd.Flags().String("zone", "", "zone name")
+	setCmd.Flags().String("zone", "", "zone name")
+	setCmd.Flags().String("value", "", "on or off")
+	cmd.AddCommand(getCmd)
+	cmd.AddCommand(setCmd)
+	return cmd
+}
+
+// cacheZoneName returns the zone given with --zone, or else as the first
+// argument, like dns list.
+func cacheZoneName(c *cobra.Command, args []string) string {
+	zoneName, _ := c.Flags().GetString("zone")
+	if zoneName == "" && len(args) > 0 {
+		zoneName = args[0]
+	}
+	return zoneName
+}
+
+// cacheToggleRun shows a cache toggle, after changing it if value is set.
+func cacheToggleRun(c *cobra.Command, args []string, t cacheToggle, value string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var raw string
+	if value == "" {
+		raw, err = t.get(c, zoneID)
+	} else {
+		raw, err = t.set(c, zoneID, value)
+	}
+	if err != nil {
+		return fmt.Errorf("Error with %s: %w", t.short, err)
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(raw))
+	}
+	s, err := zoneSettingFromRaw(raw)
+	if err != nil {
+		return err
+	}
+	return writeZoneSettings(c, []zoneSetting{s})
+}
+
+func writeCacheReserveClear(c *cobra.Command, raw string) error {
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(raw))
+	}
+	var op struct {
+		State   string `json:"state"`
+		StartTs string `json:"start_ts"`
+		EndTs   string `json:"end_ts"`
+	}
+	if err := json.Unmarshal([]byte(raw), &op); err != nil {
+		return err
+	}
+	writeTable([][]string{{op.State, op.StartTs, op.EndTs}}, "State", "Started", "Ended")
+	return nil
+}
+
+func cacheReserveStatus(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Cache.CacheReserve.Status(c.Context(), cache.CacheReserveStatusParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting Cache Reserve clear status: %w", err)
+	}
+	return writeCacheReserveClear(c, res.JSON.RawJSON())
+}
+
+func cacheReserveClear(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Cache.CacheReserve.Clear(c.Context(), cache.CacheReserveClearParams{
+		ZoneID: cloudflare.F(zoneID),
+		Body:   map[string]interface{}{},
+	})
+	if err != nil {
+		return fmt.Errorf("Error clearing Cache Reserve: %w", err)
+	}
+	return writeCacheReserveClear(c, res.JSON.RawJSON())
+}
+
+// parseVariants parses --variant flags of the form ext=type1,type2.
+func parseVariants(flags []string) (cache.VariantEditParamsValue, error) {
+	var value cache.VariantEditParamsValue
+	for _, f := range flags {
+		ext, types, ok := strings.Cut(f, "=")
+		if !ok || types == "" {
+			return value, fmt.Errorf("invalid variant %q: must be ext=type1,type2", f)
+		}
+		var list []string
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				list = append(list, t)
+			}
+		}
+
+		field := cloudflare.F(list)
+		switch strings.ToLower(strings.TrimPrefix(ext, ".")) {
+		case "avif":
+			value.AVIF = field
+		case "bmp":
+			value.BMP = field
+		case "gif":
+			value.GIF = field
+		case "jp2":
+			value.JP2 = field
+		case "jpeg":
+			value.JPEG = field
+		case "jpg":
+			value.JPG = field
+		case "jpg2":
+			value.JPG2 = field
+		case "png":
+			value.PNG = field
+		case "tif":
+			value.TIF = field
+		case "tiff":
+			value.TIFF = field
+		case "webp":
+			value.WebP = field
+		default:
+			return value, fmt.Errorf("unsupported variant extension %q", ext)
+		}
+	}
+	return value, nil
+}
+
+func writeVariants(c *cobra.Command, raw string) error {
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(raw))
+	}
+	var res struct {
+		Value      map[string][]string `json:"value"`
+		ModifiedOn string              `json:"modified_on"`
+	}
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		return err
+	}
+
+	exts := make([]string, 0, len(res.Value))
+	for ext := range res.Value {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+
+	output := make([][]string, 0, len(exts))
+	for _, ext := range exts {
+		output = append(output, []string{ext, strings.Join(res.Value[ext], ", "), res.ModifiedOn})
+	}
+	writeTable(output, "Extension", "Variants", "Modified On")
+	return nil
+}
+
+func cacheVariantsGet(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Cache.Variants.Get(c.Context(), cache.VariantGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting variants: %w", err)
+	}
+	return writeVariants(c, res.JSON.RawJSON())
+}
+
+func cacheVariantsSet(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	variants, _ := c.Flags().GetStringArray("variant")
+	if len(variants) == 0 {
+		return fmt.Errorf("error: the required flag %q was empty or not provided", "variant")
+	}
+
+	value, err := parseVariants(variants)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Cache.Variants.Edit(c.Context(), cache.VariantEditParams{
+		ZoneID: cloudflare.F(zoneID),
+		Value:  cloudflare.F(value),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating variants: %w", err)
+	}
+	return writeVariants(c, res.JSON.RawJSON())
+}
+
+func cacheVariantsDelete(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Cache.Variants.Delete(c.Context(), cache.VariantDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting variants: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var cacheRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Cache rules (http_request_cache_settings phase)",
+}
+
+var cacheRulesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the cache rules of a zone",
+	RunE:    cacheRulesList,
+}
+
+var cacheRulesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a cache rule",
+	RunE:  cacheRulesCreate,
+}
+
+var cacheRulesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a cache rule",
+	RunE:  cacheRulesUpdate,
+}
+
+var cacheRulesDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a cache rule",
+	RunE:  cacheRulesDelete,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheRulesCmd)
+	cacheRulesCmd.AddCommand(cacheRulesListCmd)
+	cacheRulesCmd.AddCommand(cacheRulesCreateCmd)
+	cacheRulesCmd.AddCommand(cacheRulesUpdateCmd)
+	cacheRulesCmd.AddCommand(cacheRulesDeleteCmd)
+
+	cacheRulesListCmd.Flags().String("zone", "", "zone name")
+
+	addCacheRuleFlags(cacheRulesCreateCmd)
+	addRulePositionFlags(cacheRulesCreateCmd)
+
+	addCacheRuleFlags(cacheRulesUpdateCmd)
+	addRulePositionFlags(cacheRulesUpdateCmd)
+	cacheRulesUpdateCmd.Flags().String("id", "", "rule ID")
+
+	cacheRulesDeleteCmd.Flags().String("zone", "", "zone name")
+	cacheRulesDeleteCmd.Flags().String("id", "", "rule ID")
+}
+
+func addCacheRuleFlags(c *cobra.Command) {
+	c.Flags().String("zone", "", "zone name")
+	c.Flags().String("expression", "", "filter expression of the requests to apply the rule to")
+	c.Flags().String("description", "", "rule description")
+	c.Flags().Bool("enabled", true, "whether the rule is enabled")
+	c.Flags().Bool("cache", false, "whether matching requests are eligible for cache; unset keeps the zone default")
+	c.Flags().Duration("edge-ttl", 0, "override the origin edge cache TTL; 0 respects the origin")
+	c.Flags().Duration("browser-ttl", 0, "override the origin browser cache TTL; 0 respects the origin")
+	c.Flags().Bool("respect-strong-etags", false, "use strong ETags between the edge and the origin")
+	c.Flags().Bool("origin-error-page-passthru", false, "use the origin error page for 4xx and 5xx responses")
+	c.Flags().String("parameters", "", "action parameters as JSON, or @file")
+}
+
+// cacheTTLMode returns the TTL setting for a duration flag.
+func cacheTTLMode(flag string, d time.Duration) (map[string]interface{}, error) {
+	if d == 0 {
+		return map[string]interface{}{"mode": "respect_origin"}, nil
+	}
+	secs, err := durationSeconds(flag, d)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"mode": "override_origin", "default": secs}, nil
+}
+
+// applyCacheRuleFlags merges the flags given on the command line into the
+// set_cache_settings action parameters.
+func applyCacheRuleFlags(c *cobra.Command, params map[string]interface{}) error {
+	if raw, _ := c.Flags().GetString("parameters"); raw != "" {
+		if raw[0] == '@' {
+			b, err := os.ReadFile(raw[1:])
+			if err != nil {
+				return err
+			}
+			raw = string(b)
+		}
+		if err := json.Unmarshal([]byte(raw), &params); err != nil {
+			return fmt.Errorf("invalid action parameters: %w", err)
+		}
+	}
+
+	for _, b := range []struct{ flag, key string }{
+		{"cache", "cache"},
+		{"respect-strong-etags", "respect_strong_etags"},
+		{"origin-error-page-passthru", "origin_error_page_passthru"},
+	} {
+		if c.Flags().Changed(b.flag) {
+			params[b.key], _ = c.Flags().GetBool(b.flag)
+		}
+	}
+
+	for _, ttl := range []struct{ flag, key string }{{"edge-ttl", "edge_ttl"}, {"browser-ttl", "browser_ttl"}} {
+		if !c.Flags().Changed(ttl.flag) {
+			continue
+		}
+		d, _ := c.Flags().GetDuration(ttl.flag)
+		setting, err := cacheTTLMode(ttl.flag, d)
+		if err != nil {
+			return err
+		}
+		params[ttl.key] = setting
+	}
+	return nil
+}
+
+func formatCacheTTL(v interface{}) string {
+	ttl, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if secs, ok := ttl["default"].(float64); ok && ttl["mode"] == "override_origin" {
+		return (time.Duration(secs) * time.Second).String()
+	}
+	mode, _ := ttl["mode"].(string)
+	return mode
+}
+
+func formatCacheRule(r rulesets.RulesetGetResponseRule) []string {
+	params := map[string]interface{}{}
+	if !r.JSON.ActionParameters.IsNull() {
+		_ = json.Unmarshal([]byte(r.JSON.ActionParameters.Raw()), &params)
+	}
+	cache := ""
+	if v, ok := params["cache"].(bool); ok {
+		cache = formatBool(v)
+	}
+	return []string{
+		r.ID,
+		r.Description,
+		cache,
+		formatCacheTTL(params["edge_ttl"]),
+		formatCacheTTL(params["browser_ttl"]),
+		formatBool(r.Enabled),
+		r.Expression,
+	}
+}
+
+func writeCacheRules(c *cobra.Command, rs *rulesets.RulesetGetResponse) error {
+	if jsonOutput(c) {
+		if rs == nil {
+			return writeJSON([]interface{}{})
+		}
+		return writeJSON(json.RawMessage(rs.JSON.Rules.Raw()))
+	}
+
+	output := make([][]string, 0)
+	if rs != nil {
+		for _, r := range rs.Rules {
+			output = append(output, formatCacheRule(r))
+		}
+	}
+	writeTable(output, "ID", "Description", "Cache", "Edge TTL", "Browser TTL", "Enabled", "Expression")
+	return nil
+}
+
+func cacheRulesList(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestCacheSettings)
+	if err != nil {
+		return fmt.Errorf("Error listing cache rules: %w", err)
+	}
+	return writeCacheRules(c, rs)
+}
+
+func cacheRulesCreate(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	if err := checkFlags(c, "expression"); err != nil {
+		return err
+	}
+	expression, _ := c.Flags().GetString("expression")
+	description, _ := c.Flags().GetString("description")
+	enabled, _ := c.Flags().GetBool("enabled")
+
+	if err := validateExpression(expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	params := map[string]interface{}{}
+	if err := applyCacheRuleFlags(c, params); err != nil {
+		return err
+	}
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rule := rulesetRule{
+		Action:           string(rulesets.RulesetGetResponseRulesActionSetCacheSettings),
+		ActionParameters: params,
+		Description:      description,
+		Expression:       expression,
+		Enabled:          enabled,
+	}
+	rs, err := addPhaseRule(c, "", zoneID, rulesets.PhaseHTTPRequestCacheSettings, rule, position)
+	if err != nil {
+		return fmt.Errorf("Error creating cache rule: %w", err)
+	}
+	return writeCacheRules(c, rs)
+}
+
+func cacheRulesUpdate(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestCacheSettings)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no cache rules", zoneName)
+	}
+	existing, ok := findRulesetRule(rs, id)
+	if !ok {
+		return fmt.Errorf("cache rule %q not found", id)
+	}
+
+	rule := rulesetRuleFromExisting(existing)
+	params := map[string]interface{}{}
+	if !existing.JSON.ActionParameters.IsNull() {
+		if err := json.Unmarshal([]byte(existing.JSON.ActionParameters.Raw()), &params); err != nil {
+			return err
+		}
+	}
+	if err := applyCacheRuleFlags(c, params); err != nil {
+		return err
+	}
+	rule.ActionParameters = params
+
+	if c.Flags().Changed("expression") {
+		rule.Expression, _ = c.Flags().GetString("expression")
+		if err := validateExpression(rule.Expression); err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+	}
+	if c.Flags().Changed("description") {
+		rule.Description, _ = c.Flags().GetString("description")
+	}
+	if c.Flags().Changed("enabled") {
+		rule.Enabled, _ = c.Flags().GetBool("enabled")
+	}
+
+	rs, err = editRulesetRule(c, "", zoneID, rs.ID, id, rule, position)
+	if err != nil {
+		return fmt.Errorf("Error updating cache rule: %w", err)
+	}
+	return writeCacheRules(c, rs)
+}
+
+func cacheRulesDelete(c *cobra.Command, args []string) error {
+	zoneName := cacheZoneName(c, args)
+	if zoneName == "" {
+		return c.Help()
+	}
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestCacheSettings)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no cache rules", zoneName)
+	}
+
+	rs, err = deleteRulesetRule(c, "", zoneID, rs.ID, id)
+	if err != nil {
+		return fmt.Errorf("Error deleting cache rule: %w", err)
+	}
+	return writeCacheRules(c, rs)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestChunkPurge(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+
+	chunks := chunkPurge("files", items, 2)
+	var got [][]string
+	for _, p := range chunks {
+		if p.Kind != "files" {
+			t.Errorf("chunk kind = %q; want files", p.Kind)
+		}
+		got = append(got, p.Items)
+	}
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("chunkPurge() = %v; want %v", got, want)
+	}
+
+	if chunks := chunkPurge("tags", nil, 30); len(chunks) != 0 {
+		t.Errorf("chunkPurge(nil) = %v; want no chunks", chunks)
+	}
+}
+
+func TestParseVariants(t *testing.T) {
+	value, err := parseVariants([]string{"jpeg=image/webp, image/avif", ".png=image/webp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := value.JPEG.Value, []string{"image/webp", "image/avif"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("jpeg variants = %v; want %v", got, want)
+	}
+	if got, want := value.PNG.Value, []string{"image/webp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("png variants = %v; want %v", got, want)
+	}
+
+	for _, v := range []string{"jpeg", "jpeg=", "svg=image/webp"} {
+		if _, err := parseVariants([]string{v}); err == nil {
+			t.Errorf("parseVariants(%q) = nil error; want error", v)
+		}
+	}
+}
+
+func newCacheRuleTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	c := &cobra.Command{Use: "test"}
+	addCacheRuleFlags(c)
+	if err := c.Flags().Parse(args); err != nil {
+		t.Fatalf("Parse(%v) failed: %v", args, err)
+	}
+	return c
+}
+
+func TestApplyCacheRuleFlags(t *testing.T) {
+	c := newCacheRuleTestCmd(t, "--edge-ttl", "2h", "--browser-ttl", "0", "--respect-strong-etags")
+	params := map[string]interface{}{}
+	if err := applyCacheRuleFlags(c, params); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"respect_strong_etags": true,
+		"edge_ttl":             map[string]interface{}{"mode": "override_origin", "default": int64(7200)},
+		"browser_ttl":          map[string]interface{}{"mode": "respect_origin"},
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("applyCacheRuleFlags() = %v; want %v", params, want)
+	}
+
+	// Updates keep the existing parameters that were not given.
+	c = newCacheRuleTestCmd(t, "--cache=false")
+	params = map[string]interface{}{"cache": true, "edge_ttl": "kept"}
+	if err := applyCacheRuleFlags(c, params); err != nil {
+		t.Fatal(err)
+	}
+	if params["cache"] != false || params["edge_ttl"] != "kept" {
+		t.Errorf("applyCacheRuleFlags() update = %v", params)
+	}
+
+	c = newCacheRuleTestCmd(t, "--edge-ttl", "1500ms")
+	if err := applyCacheRuleFlags(c, map[string]interface{}{}); err == nil {
+		t.Error("applyCacheRuleFlags() with fractional TTL = nil error; want error")
+	}
+}
@@ -0,0 +1,699 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/custom_hostnames"
+	"github.com/cloudflare/cloudflare-go/v6/shared"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var customHostnamesCmd = &cobra.Command{
+	Use:     "custom-hostnames",
+	Aliases: []string{"ch"},
+	Short:   "Custom hostnames (SSL for SaaS)",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var customHostnamesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the custom hostnames of a zone",
+	RunE:    customHostnamesList,
+}
+
+var customHostnamesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a custom hostname",
+	RunE:  customHostnamesCreate,
+}
+
+var customHostnamesGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a custom hostname and the records that validate it",
+	RunE:  customHostnamesGet,
+}
+
+var customHostnamesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the SSL settings or origin of a custom hostname",
+	RunE:  customHostnamesUpdate,
+}
+
+var customHostnamesDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a custom hostname",
+	RunE:  customHostnamesDelete,
+}
+
+var customHostnamesRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Retry the validation of a custom hostname",
+	RunE:  customHostnamesRefresh,
+}
+
+// customHostnamePollInterval is how often --wait checks the validation status.
+var customHostnamePollInterval = 10 * time.Second
+
+func init() {
+	rootCmd.AddCommand(customHostnamesCmd)
+	customHostnamesCmd.AddCommand(customHostnamesListCmd)
+	customHostnamesCmd.AddCommand(customHostnamesCreateCmd)
+	customHostnamesCmd.AddCommand(customHostnamesGetCmd)
+	customHostnamesCmd.AddCommand(customHostnamesUpdateCmd)
+	customHostnamesCmd.AddCommand(customHostnamesDeleteCmd)
+	customHostnamesCmd.AddCommand(customHostnamesRefreshCmd)
+
+	customHostnamesListCmd.Flags().String("zone", "", "zone name")
+	customHostnamesListCmd.Flags().String("hostname", "", "only list hostnames matching this name")
+
+	customHostnamesCreateCmd.Flags().String("zone", "", "zone name")
+	customHostnamesCreateCmd.Flags().String("hostname", "", "custom hostname")
+	addCustomHostnameSSLFlags(customHostnamesCreateCmd)
+	customHostnamesCreateCmd.Flags().String("custom-origin-server", "", "origin server for this hostname instead of the fallback origin")
+	customHostnamesCreateCmd.Flags().String("custom-origin-sni", "", "SNI sent to the custom origin server")
+	customHostnamesCreateCmd.Flags().StringArray("metadata", nil, "custom metadata as key=value (repeatable)")
+	addCustomHostnameWaitFlags(customHostnamesCreateCmd)
+
+	for _, c := range []*cobra.Command{customHostnamesGetCmd, customHostnamesUpdateCmd, customHostnamesDeleteCmd, customHostnamesRefreshCmd} {
+		c.Flags().String("zone", "", "zone name")
+		c.Flags().String("id", "", "custom hostname ID")
+		c.Flags().String("hostname", "", "custom hostname, instead of --id")
+	}
+
+	addCustomHostnameSSLFlags(customHostnamesUpdateCmd)
+	customHostnamesUpdateCmd.Flags().String("custom-origin-server", "", "origin server for this hostname, empty for the fallback origin")
+	customHostnamesUpdateCmd.Flags().String("custom-origin-sni", "", "SNI sent to the custom origin server")
+	customHostnamesUpdateCmd.Flags().StringArray("metadata", nil, "replace the custom metadata with key=value pairs (repeatable)")
+	addCustomHostnameWaitFlags(customHostnamesUpdateCmd)
+
+	addCustomHostnameWaitFlags(customHostnamesRefreshCmd)
+}
+
+func addCustomHostnameSSLFlags(c *cobra.Command) {
+	c.Flags().String("ssl-method", "http", "domain control validation method: http, txt or email")
+	c.Flags().String("ssl-type", "dv", "certificate validation type: dv")
+	c.Flags().String("ca", "", "certificate authority: google, lets_encrypt or ssl_com (default chosen by Cloudflare)")
+	c.Flags().Bool("wildcard", false, "also cover *.<hostname>")
+	c.Flags().String("min-tls-version", "", "minimum TLS version: 1.0, 1.1, 1.2 or 1.3")
+}
+
+func addCustomHostnameWaitFlags(c *cobra.Command) {
+	c.Flags().Bool("wait", false, "wait until the hostname and its certificate are active")
+	c.Flags().Duration("timeout", 15*time.Minute, "how long to wait with --wait")
+}
+
+// customHostname holds the fields of the different custom hostname responses
+// that flarectl uses, decoded from their raw JSON.
+type customHostname struct {
+	ID                    string `json:"id"`
+	Hostname              string `json:"hostname"`
+	Status                string `json:"status"`
+	CustomOriginServer    string `json:"custom_origin_server"`
+	OwnershipVerification struct {
+		Type  string `json:"type"`
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"ownership_verification"`
+	OwnershipVerificationHTTP struct {
+		HTTPURL  string `json:"http_url"`
+		HTTPBody string `json:"http_body"`
+	} `json:"ownership_verification_http"`
+	VerificationErrors []string `json:"verification_errors"`
+	SSL                struct {
+		Status            string `json:"status"`
+		Method            string `json:"method"`
+		Type              string `json:"type"`
+		Wildcard          bool   `json:"wildcard"`
+		ValidationRecords []struct {
+			TXTName  string   `json:"txt_name"`
+			TXTValue string   `json:"txt_value"`
+			HTTPURL  string   `json:"http_url"`
+			HTTPBody string   `json:"http_body"`
+			Emails   []string `json:"emails"`
+		} `json:"validation_records"`
+		ValidationErrors []struct {
+			Message string `json:"message"`
+		} `json:"validation_errors"`
+	} `json:"ssl"`
+
+	raw json.RawMessage
+}
+
+func customHostnameFromRaw(raw string) (customHostname, error) {
+	var h customHostname
+	if err := json.Unmarshal([]byte(raw), &h); err != nil {
+		return h, err
+	}
+	h.raw = json.RawMessage(raw)
+	return h, nil
+}
+
+// active reports whether both the hostname and its certificate are active.
+func (h customHostname) active() bool {
+	return h.Status == "active" && h.SSL.Status == "active"
+}
+
+// failed reports whether validation stopped and will not go on by itself.
+func (h customHostname) failed() bool {
+	return h.Status == "blocked" || h.Status == "moved" || strings.HasSuffix(h.SSL.Status, "timed_out")
+}
+
+// validationRecords returns the records the owner of the hostname still has to
+// add, as rows of hostname, purpose, type, name and value.
+func (h customHostname) validationRecords() [][]string {
+	var rows [][]string
+	if h.Status != "active" {
+		if h.OwnershipVerification.Name != "" {
+			rows = append(rows, []string{h.Hostname, "ownership", strings.ToUpper(h.OwnershipVerification.Type), h.OwnershipVerification.Name, h.OwnershipVerification.Value})
+		}
+		if h.OwnershipVerificationHTTP.HTTPURL != "" {
+			rows = append(rows, []string{h.Hostname, "ownership", "HTTP", h.OwnershipVerificationHTTP.HTTPURL, h.OwnershipVerificationHTTP.HTTPBody})
+		}
+	}
+	if h.SSL.Status != "active" {
+		for _, r := range h.SSL.ValidationRecords {
+			switch {
+			case r.TXTName != "":
+				rows = append(rows, []string{h.Hostname, "certificate", "TXT", r.TXTName, r.TXTValue})
+			case r.HTTPURL != "":
+				rows = append(rows, []string{h.Hostname, "certificate", "HTTP", r.HTTPURL, r.HTTPBody})
+			case len(r.Emails) > 0:
+				rows = append(rows, []string{h.Hostname, "certificate", "EMAIL", strings.Join(r.Emails, ", "), ""})
+			}
+		}
+	}
+	return rows
+}
+
+// problems returns the verification and validation errors of the hostname.
+func (h customHostname) problems() []string {
+	errs := slices.Clone(h.VerificationErrors)
+	for _, e := range h.SSL.ValidationErrors {
+		errs = append(errs, e.Message)
+	}
+	return errs
+}
+
+// customHostnameSpec describes a custom hostname to create, from flags or a
+// line of a CSV import.
+type customHostnameSpec struct {
+	Hostname           string
+	Method             string
+	Type               string
+	CA                 string
+	Wildcard           bool
+	MinTLSVersion      string
+	CustomOriginServer string
+	CustomOriginSNI    string
+	Metadata           map[string]string
+}
+
+func checkCustomHostnameSSL(method, sslType, ca, minTLS string, wildcard bool) error {
+	if !custom_hostnames.DCVMethod(method).IsKnown() {
+		return fmt.Errorf("invalid SSL method %q: must be http, txt or email", method)
+	}
+	if !custom_hostnames.DomainValidationType(sslType).IsKnown() {
+		return fmt.Errorf("invalid SSL type %q: must be dv", sslType)
+	}
+	if ca != "" && (!shared.CertificateCA(ca).IsKnown() || ca == string(shared.CertificateCADigicert)) {
+		return fmt.Errorf("invalid certificate authority %q: must be google, lets_encrypt or ssl_com", ca)
+	}
+	if minTLS != "" && !custom_hostnames.CustomHostnameNewParamsSSLSettingsMinTLSVersion(minTLS).IsKnown() {
+		return fmt.Errorf("invalid minimum TLS version %q: must be 1.0, 1.1, 1.2 or 1.3", minTLS)
+	}
+	if wildcard && method == string(custom_hostnames.DCVMethodHTTP) {
+		return errors.New("wildcard certificates cannot be validated with the http method")
+	}
+	return nil
+}
+
+func (s customHostnameSpec) validate() error {
+	if err := checkOriginCAHostnames([]string{s.Hostname}); err != nil {
+		return err
+	}
+	return checkCustomHostnameSSL(s.Method, s.Type, s.CA, s.MinTLSVersion, s.Wildcard)
+}
+
+func (s customHostnameSpec) newParams(zoneID string) custom_hostnames.CustomHostnameNewParams {
+	sslParams := custom_hostnames.CustomHostnameNewParamsSSL{
+		Method:   cloudflare.F(custom_hostnames.DCVMethod(s.Method)),
+		Type:     cloudflare.F(custom_hostnames.DomainValidationType(s.Type)),
+		Wildcard: cloudflare.F(s.Wildcard),
+	}
+	if s.CA != "" {
+		sslParams.CertificateAuthority = cloudflare.F(shared.CertificateCA(s.CA))
+	}
+	if s.MinTLSVersion != "" {
+		sslParams.Settings = cloudflare.F(custom_hostnames.CustomHostnameNewParamsSSLSettings{
+			MinTLSVersion: cloudflare.F(custom_hostnames.CustomHostnameNewParamsSSLSettingsMinTLSVersion(s.MinTLSVersion)),
+		})
+	}
+	params := custom_hostnames.CustomHostnameNewParams{
+		ZoneID:   cloudflare.F(zoneID),
+		Hostname: cloudflare.F(s.Hostname),
+		SSL:      cloudflare.F(sslParams),
+	}
+	if len(s.Metadata) > 0 {
+		params.CustomMetadata = cloudflare.F(s.Metadata)
+	}
+	return params
+}
+
+func parseCustomHostnameMetadata(pairs []string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, p := range pairs {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata %q: must be key=value", p)
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+// createCustomHostname creates a custom hostname. The create call does not
+// take a custom origin, so that is set with a second call.
+func createCustomHostname(c *cobra.Command, zoneID string, s customHostnameSpec) (customHostname, error) {
+	res, err := client.CustomHostnames.New(c.Context(), s.newParams(zoneID))
+	if err != nil {
+		return customHostname{}, fmt.Errorf("Error creating custom hostname %s: %w", s.Hostname, err)
+	}
+	h, err := customHostnameFromRaw(res.JSON.RawJSON())
+	if err != nil || s.CustomOriginServer == "" {
+		return h, err
+	}
+
+	params := custom_hostnames.CustomHostnameEditParams{
+		ZoneID:             cloudflare.F(zoneID),
+		CustomOriginServer: cloudflare.F(s.CustomOriginServer),
+	}
+	if s.CustomOriginSNI != "" {
+		params.CustomOriginSNI = cloudflare.F(s.CustomOriginSNI)
+	}
+	edited, err := client.CustomHostnames.Edit(c.Context(), h.ID, params)
+	if err != nil {
+		return h, fmt.Errorf("Error setting the custom origin of %s (hostname %s was created): %w", s.Hostname, h.ID, err)
+	}
+	return customHostnameFromRaw(edited.JSON.RawJSON())
+}
+
+// findCustomHostname gets the custom hostname given by --id or --hostname.
+func findCustomHostname(c *cobra.Command, zoneID string) (customHostname, error) {
+	id, _ := c.Flags().GetString("id")
+	hostname, _ := c.Flags().GetString("hostname")
+
+	if id != "" {
+		res, err := client.CustomHostnames.Get(c.Context(), id, custom_hostnames.CustomHostnameGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return customHostname{}, fmt.Errorf("Error getting custom hostname: %w", err)
+		}
+		return customHostnameFromRaw(res.JSON.RawJSON())
+	}
+	if hostname == "" {
+		return customHostname{}, errors.New("either --id or --hostname is required")
+	}
+
+	iter := client.CustomHostnames.ListAutoPaging(c.Context(), custom_hostnames.CustomHostnameListParams{
+		ZoneID:   cloudflare.F(zoneID),
+		Hostname: cloudflare.F(hostname),
+	})
+	for iter.Next() {
+		h := iter.Current()
+		if strings.EqualFold(h.Hostname, hostname) {
+			return customHostnameFromRaw(h.JSON.RawJSON())
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return customHostname{}, fmt.Errorf("Error getting custom hostname: %w", err)
+	}
+	return customHostname{}, fmt.Errorf("custom hostname %s not found", hostname)
+}
+
+func formatCustomHostname(h customHostname) []string {
+	return []string{
+		h.ID,
+		h.Hostname,
+		h.Status,
+		h.SSL.Status,
+		h.SSL.Method,
+		h.CustomOriginServer,
+	}
+}
+
+func writeCustomHostnames(c *cobra.Command, list []customHostname) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(list))
+		for _, h := range list {
+			raw = append(raw, h.raw)
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(list))
+	for _, h := range list {
+		output = append(output, formatCustomHostname(h))
+	}
+	writeTable(output, "ID", "Hostname", "Status", "SSL Status", "SSL Method", "Custom Origin")
+	return nil
+}
+
+func writeValidationRecords(records [][]string) {
+	writeTable(records, "Hostname", "Purpose", "Type", "Name", "Value")
+}
+
+// showCustomHostname prints a custom hostname with the records still needed
+// to validate it and any errors.
+func showCustomHostname(c *cobra.Command, h customHostname) error {
+	if jsonOutput(c) {
+		return writeJSON(h.raw)
+	}
+	if err := writeCustomHostnames(c, []customHostname{h}); err != nil {
+		return err
+	}
+	if records := h.validationRecords(); len(records) > 0 {
+		fmt.Println()
+		writeValidationRecords(records)
+	}
+	for _, e := range h.problems() {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", e)
+	}
+	return nil
+}
+
+// waitCustomHostname polls a custom hostname until it and its certificate are
+// active. The validation records are printed whenever they change.
+func waitCustomHostname(c *cobra.Command, zoneID string, h customHostname) (customHostname, error) {
+	timeout, _ := c.Flags().GetDuration("timeout")
+	deadline := time.Now().Add(timeout)
+
+	var shown [][]string
+	status := ""
+	for {
+		if records := h.validationRecords(); !jsonOutput(c) && len(records) > 0 && !slices.EqualFunc(records, shown, slices.Equal[[]string]) {
+			writeValidationRecords(records)
+			shown = records
+		}
+		if s := h.Status + "/" + h.SSL.Status; s != status {
+			fmt.Fprintf(os.Stderr, "%s: hostname %s, certificate %s\n", h.Hostname, h.Status, h.SSL.Status)
+			status = s
+		}
+		if h.active() {
+			return h, nil
+		}
+		if h.failed() {
+			return h, fmt.Errorf("validation of %s stopped: hostname %s, certificate %s %v", h.Hostname, h.Status, h.SSL.Status, h.problems())
+		}
+		if time.Now().After(deadline) {
+			return h, fmt.Errorf("%s is not active after %s: hostname %s, certificate %s", h.Hostname, timeout, h.Status, h.SSL.Status)
+		}
+
+		select {
+		case <-c.Context().Done():
+			return h, c.Context().Err()
+		case <-time.After(customHostnamePollInterval):
+		}
+		res, err := client.CustomHostnames.Get(c.Context(), h.ID, custom_hostnames.CustomHostnameGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return h, fmt.Errorf("Error getting custom hostname: %w", err)
+		}
+		if h, err = customHostnameFromRaw(res.JSON.RawJSON()); err != nil {
+			return h, err
+		}
+	}
+}
+
+// finishCustomHostname shows a created or changed custom hostname, after
+// waiting for it to become active with --wait.
+func finishCustomHostname(c *cobra.Command, zoneID string, h customHostname) error {
+	wait, _ := c.Flags().GetBool("wait")
+	if !wait {
+		return showCustomHostname(c, h)
+	}
+	h, err := waitCustomHostname(c, zoneID, h)
+	if err != nil {
+		return err
+	}
+	if jsonOutput(c) {
+		return writeJSON(h.raw)
+	}
+	return writeCustomHostnames(c, []customHostname{h})
+}
+
+func customHostnamesList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	hostname, _ := c.Flags().GetString("hostname")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := custom_hostnames.CustomHostnameListParams{ZoneID: cloudflare.F(zoneID)}
+	if hostname != "" {
+		params.Hostname = cloudflare.F(hostname)
+	}
+	var list []customHostname
+	iter := client.CustomHostnames.ListAutoPaging(c.Context(), params)
+	for iter.Next() {
+		h, err := customHostnameFromRaw(iter.Current().JSON.RawJSON())
+		if err != nil {
+			return err
+		}
+		list = append(list, h)
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing custom hostnames: %w", err)
+	}
+	return writeCustomHostnames(c, list)
+}
+
+func customHostnamesCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "hostname"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	spec := customHostnameSpec{}
+	spec.Hostname, _ = c.Flags().GetString("hostname")
+	spec.Method, _ = c.Flags().GetString("ssl-method")
+	spec.Type, _ = c.Flags().GetString("ssl-type")
+	spec.CA, _ = c.Flags().GetString("ca")
+	spec.Wildcard, _ = c.Flags().GetBool("wildcard")
+	spec.MinTLSVersion, _ = c.Flags().GetString("min-tls-version")
+	spec.CustomOriginServer, _ = c.Flags().GetString("custom-origin-server")
+	spec.CustomOriginSNI, _ = c.Flags().GetString("custom-origin-sni")
+	metadata, _ := c.Flags().GetStringArray("metadata")
+
+	var err error
+	if spec.Metadata, err = parseCustomHostnameMetadata(metadata); err != nil {
+		return err
+	}
+	if err := spec.validate(); err != nil {
+		return err
+	}
+	if spec.CustomOriginSNI != "" && spec.CustomOriginServer == "" {
+		return errors.New("--custom-origin-sni requires --custom-origin-server")
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	h, err := createCustomHostname(c, zoneID, spec)
+	if err != nil {
+		return err
+	}
+	return finishCustomHostname(c, zoneID, h)
+}
+
+func customHostnamesGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	h, err := findCustomHostname(c, zoneID)
+	if err != nil {
+		return err
+	}
+	return showCustomHostname(c, h)
+}
+
+// customHostnameEditSSL returns the SSL settings of an update: the changed
+// flags on top of the current settings of h.
+func customHostnameEditSSL(c *cobra.Command, h customHostname) (custom_hostnames.CustomHostnameEditParamsSSL, error) {
+	method, sslType, wildcard := h.SSL.Method, h.SSL.Type, h.SSL.Wildcard
+	if c.Flags().Changed("ssl-method") || method == "" {
+		method, _ = c.Flags().GetString("ssl-method")
+	}
+	if c.Flags().Changed("ssl-type") || sslType == "" {
+		sslType, _ = c.Flags().GetString("ssl-type")
+	}
+	if c.Flags().Changed("wildcard") {
+		wildcard, _ = c.Flags().GetBool("wildcard")
+	}
+	ca, _ := c.Flags().GetString("ca")
+	minTLS, _ := c.Flags().GetString("min-tls-version")
+
+	if err := checkCustomHostnameSSL(method, sslType, ca, minTLS, wildcard); err != nil {
+		return custom_hostnames.CustomHostnameEditParamsSSL{}, err
+	}
+
+	sslParams := custom_hostnames.CustomHostnameEditParamsSSL{
+		Method:   cloudflare.F(custom_hostnames.DCVMethod(method)),
+		Type:     cloudflare.F(custom_hostnames.DomainValidationType(sslType)),
+		Wildcard: cloudflare.F(wildcard),
+	}
+	if ca != "" {
+		sslParams.CertificateAuthority = cloudflare.F(shared.CertificateCA(ca))
+	}
+	if minTLS != "" {
+		sslParams.Settings = cloudflare.F(custom_hostnames.CustomHostnameEditParamsSSLSettings{
+			MinTLSVersion: cloudflare.F(custom_hostnames.CustomHostnameEditParamsSSLSettingsMinTLSVersion(minTLS)),
+		})
+	}
+	return sslParams, nil
+}
+
+func customHostnamesUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	h, err := findCustomHostname(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	params := custom_hostnames.CustomHostnameEditParams{ZoneID: cloudflare.F(zoneID)}
+	changed := false
+	for _, flag := range []string{"ssl-method", "ssl-type", "ca", "wildcard", "min-tls-version"} {
+		if c.Flags().Changed(flag) {
+			sslParams, err := customHostnameEditSSL(c, h)
+			if err != nil {
+				return err
+			}
+			params.SSL = cloudflare.F(sslParams)
+			changed = true
+			break
+		}
+	}
+	if c.Flags().Changed("custom-origin-server") {
+		origin, _ := c.Flags().GetString("custom-origin-server")
+		params.CustomOriginServer = cloudflare.F(origin)
+		changed = true
+	}
+	if c.Flags().Changed("custom-origin-sni") {
+		sni, _ := c.Flags().GetString("custom-origin-sni")
+		params.CustomOriginSNI = cloudflare.F(sni)
+		changed = true
+	}
+	if c.Flags().Changed("metadata") {
+		pairs, _ := c.Flags().GetStringArray("metadata")
+		metadata, err := parseCustomHostnameMetadata(pairs)
+		if err != nil {
+			return err
+		}
+		params.CustomMetadata = cloudflare.F(metadata)
+		changed = true
+	}
+	if !changed {
+		return errors.New("nothing to update: use --ssl-method, --ssl-type, --ca, --wildcard, --min-tls-version, --custom-origin-server, --custom-origin-sni or --metadata")
+	}
+
+	res, err := client.CustomHostnames.Edit(c.Context(), h.ID, params)
+	if err != nil {
+		return fmt.Errorf("Error updating custom hostname: %w", err)
+	}
+	if h, err = customHostnameFromRaw(res.JSON.RawJSON()); err != nil {
+		return err
+	}
+	return finishCustomHostname(c, zoneID, h)
+}
+
+func customHostnamesDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	h, err := findCustomHostname(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CustomHostnames.Delete(c.Context(), h.ID, custom_hostnames.CustomHostnameDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting custom hostname: %w", err)
+	}
+	fmt.Printf("Deleted custom hostname %s (%s)\n", h.Hostname, h.ID)
+	return nil
+}
+
+// customHostnamesRefresh sends the current SSL method and type again, which
+// makes Cloudflare retry the validation of the hostname and its certificate.
+func customHostnamesRefresh(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	h, err := findCustomHostname(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	method, sslType := h.SSL.Method, h.SSL.Type
+	if method == "" {
+		method = string(custom_hostnames.DCVMethodHTTP)
+	}
+	if sslType == "" {
+		sslType = string(custom_hostnames.DomainValidationTypeDv)
+	}
+	res, err := client.CustomHostnames.Edit(c.Context(), h.ID, custom_hostnames.CustomHostnameEditParams{
+		ZoneID: cloudflare.F(zoneID),
+		SSL: cloudflare.F(custom_hostnames.CustomHostnameEditParamsSSL{
+			Method:   cloudflare.F(custom_hostnames.DCVMethod(method)),
+			Type:     cloudflare.F(custom_hostnames.DomainValidationType(sslType)),
+			Wildcard: cloudflare.F(h.SSL.Wildcard),
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("Error refreshing custom hostname: %w", err)
+	}
+	if h, err = customHostnameFromRaw(res.JSON.RawJSON()); err != nil {
+		return err
+	}
+	return finishCustomHostname(c, zoneID, h)
+}
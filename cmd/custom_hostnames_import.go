@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/custom_hostnames"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var customHostnamesImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create custom hostnames from a CSV file",
+	Long: `Create the custom hostnames listed in a CSV file. Hostnames that already
+exist in the zone are left alone.
+
+Each line holds the hostname, optionally followed by the SSL method, SSL type,
+certificate authority, wildcard, minimum TLS version, custom origin server and
+custom origin SNI. A header line naming the columns may be given instead:
+
+  hostname,ssl_method,custom_origin_server
+  shop.customer1.com,txt,
+  www.customer2.com,http,origin2.example.com
+
+The validation records for the created hostnames are printed at the end.`,
+	RunE: customHostnamesImport,
+}
+
+func init() {
+	customHostnamesCmd.AddCommand(customHostnamesImportCmd)
+	customHostnamesImportCmd.Flags().String("zone", "", "zone name")
+	customHostnamesImportCmd.Flags().StringP("file", "f", "", "CSV file, - for stdin")
+	customHostnamesImportCmd.Flags().String("ssl-method", "http", "validation method for lines without one")
+	customHostnamesImportCmd.Flags().String("ssl-type", "dv", "validation type for lines without one")
+	customHostnamesImportCmd.Flags().Bool("dry-run", false, "show what would be created without changing anything")
+}
+
+// customHostnameColumns lists the CSV columns in their default order and the
+// names accepted for them in a header line.
+var customHostnameColumns = []struct {
+	names []string
+	set   func(s *customHostnameSpec, v string) error
+}{
+	{[]string{"hostname"}, func(s *customHostnameSpec, v string) error { s.Hostname = strings.ToLower(v); return nil }},
+	{[]string{"ssl_method", "method"}, func(s *customHostnameSpec, v string) error { s.Method = v; return nil }},
+	{[]string{"ssl_type", "type"}, func(s *customHostnameSpec, v string) error { s.Type = v; return nil }},
+	{[]string{"ca", "certificate_authority"}, func(s *customHostnameSpec, v string) error { s.CA = v; return nil }},
+	{[]string{"wildcard"}, func(s *customHostnameSpec, v string) error { return parseCSVBool(v, &s.Wildcard) }},
+	{[]string{"min_tls_version"}, func(s *customHostnameSpec, v string) error { s.MinTLSVersion = v; return nil }},
+	{[]string{"custom_origin_server"}, func(s *customHostnameSpec, v string) error { s.CustomOriginServer = v; return nil }},
+	{[]string{"custom_origin_sni"}, func(s *customHostnameSpec, v string) error { s.CustomOriginSNI = v; return nil }},
+}
+
+// customHostnameColumn returns the index in customHostnameColumns of the
+// column named in a header line, or -1.
+func customHostnameColumn(name string) int {
+	name = strings.TrimSpace(name)
+	for i, c := range customHostnameColumns {
+		for _, n := range c.names {
+			if strings.EqualFold(n, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseCustomHostnamesCSV reads custom hostnames from CSV. Lines starting with
+// # are ignored. Lines without a method or type get the given defaults, and a
+// hostname may only appear once.
+func parseCustomHostnamesCSV(r io.Reader, method, sslType string) ([]customHostnameSpec, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	order := make([]int, len(customHostnameColumns))
+	for i := range order {
+		order[i] = i
+	}
+
+	var specs []customHostnameSpec
+	seen := map[string]int{}
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first && customHostnameColumn(record[0]) >= 0 {
+			order = order[:0]
+			for _, name := range record {
+				col := customHostnameColumn(name)
+				if col < 0 {
+					return nil, fmt.Errorf("line %d: unknown column %q", line, name)
+				}
+				order = append(order, col)
+			}
+			continue
+		}
+
+		if len(record) > len(order) {
+			return nil, fmt.Errorf("line %d: too many fields", line)
+		}
+		var s customHostnameSpec
+		for i, v := range record {
+			col := customHostnameColumns[order[i]]
+			if err := col.set(&s, strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q", line, col.names[0], v)
+			}
+		}
+		if s.Method == "" {
+			s.Method = method
+		}
+		if s.Type == "" {
+			s.Type = sslType
+		}
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if s.CustomOriginSNI != "" && s.CustomOriginServer == "" {
+			return nil, fmt.Errorf("line %d: custom_origin_sni requires custom_origin_server", line)
+		}
+		if prev, ok := seen[s.Hostname]; ok {
+			return nil, fmt.Errorf("line %d: hostname %s already used on line %d", line, s.Hostname, prev)
+		}
+		seen[s.Hostname] = line
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+// customHostnameImportResult is the outcome of importing one hostname.
+type customHostnameImportResult struct {
+	Hostname       string          `json:"hostname"`
+	Result         string          `json:"result"`
+	Error          string          `json:"error,omitempty"`
+	CustomHostname json.RawMessage `json:"custom_hostname,omitempty"`
+
+	h customHostname
+}
+
+func customHostnamesImport(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "file"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	file, _ := c.Flags().GetString("file")
+	method, _ := c.Flags().GetString("ssl-method")
+	sslType, _ := c.Flags().GetString("ssl-type")
+	dryRun, _ := c.Flags().GetBool("dry-run")
+
+	f := os.Stdin
+	if file != "-" {
+		var err error
+		if f, err = os.Open(file); err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+	specs, err := parseCustomHostnamesCSV(f, method, sslType)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", file, err)
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]customHostname{}
+	iter := client.CustomHostnames.ListAutoPaging(c.Context(), custom_hostnames.CustomHostnameListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		h, err := customHostnameFromRaw(iter.Current().JSON.RawJSON())
+		if err != nil {
+			return err
+		}
+		existing[strings.ToLower(h.Hostname)] = h
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing custom hostnames: %w", err)
+	}
+
+	results := make([]customHostnameImportResult, 0, len(specs))
+	failed := 0
+	for _, s := range specs {
+		r := customHostnameImportResult{Hostname: s.Hostname}
+		switch h, ok := existing[s.Hostname]; {
+		case ok:
+			r.Result, r.h = "exists", h
+		case dryRun:
+			r.Result = "would create"
+		default:
+			h, err := createCustomHostname(c, zoneID, s)
+			if err != nil {
+				r.Result, r.Error = "failed", err.Error()
+				failed++
+			} else {
+				r.Result = "created"
+			}
+			r.h = h
+		}
+		r.CustomHostname = r.h.raw
+		results = append(results, r)
+	}
+
+	if jsonOutput(c) {
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	} else {
+		output := make([][]string, 0, len(results))
+		var records [][]string
+		for _, r := range results {
+			result := r.Result
+			if r.Error != "" {
+				result = r.Error
+			}
+			output = append(output, []string{r.Hostname, r.h.ID, r.h.Status, r.h.SSL.Status, result})
+			if r.Result == "created" {
+				records = append(records, r.h.validationRecords()...)
+			}
+		}
+		writeTable(output, "Hostname", "ID", "Status", "SSL Status", "Result")
+		if len(records) > 0 {
+			fmt.Println()
+			writeValidationRecords(records)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d custom hostnames could not be created", failed, len(specs))
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCustomHostnameValidationRecords(t *testing.T) {
+	h, err := customHostnameFromRaw(`{
+		"id": "0d89c70d-ad9f-4843-b99f-6cc0252067e9",
+		"hostname": "app.customer.com",
+		"status": "pending",
+		"ownership_verification": {"type": "txt", "name": "_cf-custom-hostname.app.customer.com", "value": "5cc07c04-ea62-4a5a-95f0-419334a875a4"},
+		"ssl": {
+			"status": "pending_validation",
+			"method": "txt",
+			"type": "dv",
+			"validation_records": [{"txt_name": "_acme-challenge.app.customer.com", "txt_value": "ca3-574923932a82475cb8592200f1a2a23d"}],
+			"validation_errors": [{"message": "SERVFAIL looking up CAA for app.customer.com"}]
+		}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := h.validationRecords()
+	if len(records) != 2 {
+		t.Fatalf("validationRecords() = %v", records)
+	}
+	if got := strings.Join(records[0], " "); got != "app.customer.com ownership TXT _cf-custom-hostname.app.customer.com 5cc07c04-ea62-4a5a-95f0-419334a875a4" {
+		t.Errorf("ownership record = %s", got)
+	}
+	if got := strings.Join(records[1], " "); got != "app.customer.com certificate TXT _acme-challenge.app.customer.com ca3-574923932a82475cb8592200f1a2a23d" {
+		t.Errorf("certificate record = %s", got)
+	}
+	if problems := h.problems(); len(problems) != 1 {
+		t.Errorf("problems() = %v", problems)
+	}
+	if h.active() || h.failed() {
+		t.Errorf("active() = %t, failed() = %t; want false, false", h.active(), h.failed())
+	}
+
+	h.Status, h.SSL.Status = "active", "active"
+	if records := h.validationRecords(); len(records) != 0 || !h.active() {
+		t.Errorf("active hostname: validationRecords() = %v, active() = %t", records, h.active())
+	}
+}
+
+func TestParseCustomHostnamesCSV(t *testing.T) {
+	input := `# customers
+shop.customer1.com
+WWW.Customer2.com,txt,,,true
+`
+	specs, err := parseCustomHostnamesCSV(strings.NewReader(input), "http", "dv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("parseCustomHostnamesCSV() = %+v", specs)
+	}
+	if s := specs[0]; s.Hostname != "shop.customer1.com" || s.Method != "http" || s.Type != "dv" || s.Wildcard {
+		t.Errorf("first hostname = %+v", s)
+	}
+	if s := specs[1]; s.Hostname != "www.customer2.com" || s.Method != "txt" || !s.Wildcard {
+		t.Errorf("second hostname = %+v", s)
+	}
+
+	withHeader := "custom_origin_server,hostname\norigin.example.com,app.customer.com\n"
+	specs, err = parseCustomHostnamesCSV(strings.NewReader(withHeader), "http", "dv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 1 || specs[0].Hostname != "app.customer.com" || specs[0].CustomOriginServer != "origin.example.com" {
+		t.Errorf("parseCustomHostnamesCSV() with header = %+v", specs)
+	}
+
+	bad := []string{
+		"app.customer.com\napp.customer.com\n",
+		"app.customer.com,dns\n",
+		"app.customer.com,http,,,true\n",
+		"app.customer.com,http,dv,,maybe\n",
+		"hostname,bogus\napp.customer.com,x\n",
+		"not a hostname\n",
+		"app.customer.com,,,,,,,sni.example.com\n",
+	}
+	for _, in := range bad {
+		if _, err := parseCustomHostnamesCSV(strings.NewReader(in), "http", "dv"); err == nil {
+			t.Errorf("parseCustomHostnamesCSV(%q) = nil error; want error", in)
+		}
+	}
+}
+
+func TestCustomHostnameEditSSL(t *testing.T) {
+	c := &cobra.Command{Use: "test"}
+	addCustomHostnameSSLFlags(c)
+	if err := c.ParseFlags([]string{"--ssl-method", "txt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var h customHostname
+	h.SSL.Method, h.SSL.Type, h.SSL.Wildcard = "http", "dv", false
+	params, err := customHostnameEditSSL(c, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Method.Value != "txt" || params.Type.Value != "dv" {
+		t.Errorf("customHostnameEditSSL() = %s, %s; want txt, dv", params.Method.Value, params.Type.Value)
+	}
+
+	// A wildcard hostname cannot move to http validation.
+	h.SSL.Wildcard = true
+	_ = c.Flags().Set("ssl-method", "http")
+	if _, err := customHostnameEditSSL(c, h); err == nil {
+		t.Error("customHostnameEditSSL() to http for a wildcard = nil error; want error")
+	}
+}
+
+func TestParseCustomHostnameMetadata(t *testing.T) {
+	metadata, err := parseCustomHostnameMetadata([]string{"customer=42", "plan=pro=plus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata["customer"] != "42" || metadata["plan"] != "pro=plus" {
+		t.Errorf("parseCustomHostnameMetadata() = %v", metadata)
+	}
+	if _, err := parseCustomHostnameMetadata([]string{"customer"}); err == nil {
+		t.Error("parseCustomHostnameMetadata() without = nil error; want error")
+	}
+}
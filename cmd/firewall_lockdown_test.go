@@ -0,0 +1,29 @@
+package cmd
+
+import "testing"
+
+func TestParseLockdownCIDR(t *testing.T) {
+	valid := map[string]string{
+		"192.0.2.0/24":     "192.0.2.0/24",
+		"198.51.100.7/24":  "198.51.100.0/24",
+		"10.1.0.0/16":      "10.1.0.0/16",
+		"2001:db8::/32":    "2001:db8::/32",
+		"2001:db8:1::1/48": "2001:db8:1::/48",
+	}
+	for in, want := range valid {
+		got, err := parseLockdownCIDR(in)
+		if err != nil {
+			t.Errorf("parseLockdownCIDR(%q) returned error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseLockdownCIDR(%q) = %q; want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"192.0.2.1", "192.0.2.0/25", "10.0.0.0/8", "2001:db8::/56", "nonsense"} {
+		if _, err := parseLockdownCIDR(in); err == nil {
+			t.Errorf("parseLockdownCIDR(%q) = nil error; want error", in)
+		}
+	}
+}
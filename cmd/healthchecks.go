@@ -0,0 +1,574 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/healthchecks"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var healthchecksCmd = &cobra.Command{
+	Use:     "healthchecks",
+	Aliases: []string{"hc"},
+	Short:   "Standalone health checks of a zone",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var healthchecksListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List health checks",
+	RunE:    healthchecksList,
+}
+
+var healthchecksCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a health check",
+	RunE:  healthchecksCreate,
+}
+
+var healthchecksUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a health check",
+	Long:  `Update the settings given on the command line, keeping the others.`,
+	RunE:  healthchecksUpdate,
+}
+
+var healthchecksDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a health check",
+	RunE:  healthchecksDelete,
+}
+
+var healthchecksPreviewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Try out a health check without saving it",
+	Long: `Run a temporary health check with the given settings and show its result.
+The preview is deleted afterwards. The command exits with status 2 if the
+target is unhealthy.`,
+	RunE: healthchecksPreview,
+}
+
+// healthcheckPreviewPollInterval is how often preview checks for a result.
+var healthcheckPreviewPollInterval = 5 * time.Second
+
+func init() {
+	rootCmd.AddCommand(healthchecksCmd)
+	healthchecksCmd.AddCommand(healthchecksListCmd)
+	healthchecksCmd.AddCommand(healthchecksCreateCmd)
+	healthchecksCmd.AddCommand(healthchecksUpdateCmd)
+	healthchecksCmd.AddCommand(healthchecksDeleteCmd)
+	healthchecksCmd.AddCommand(healthchecksPreviewCmd)
+
+	healthchecksCmd.PersistentFlags().String("zone", "", "zone name")
+
+	addHealthcheckFlags(healthchecksCreateCmd)
+	addHealthcheckFlags(healthchecksUpdateCmd)
+	addHealthcheckFlags(healthchecksPreviewCmd)
+	healthchecksPreviewCmd.Flags().Duration("wait", 2*time.Minute, "how long to wait for a result")
+
+	for _, c := range []*cobra.Command{healthchecksUpdateCmd, healthchecksDeleteCmd} {
+		c.Flags().String("id", "", "health check ID")
+	}
+	healthchecksDeleteCmd.Flags().String("name", "", "health check name")
+}
+
+func addHealthcheckFlags(c *cobra.Command) {
+	c.Flags().String("name", "", "name of the health check")
+	c.Flags().String("address", "", "hostname or IP address of the origin to check")
+	c.Flags().String("type", "HTTP", "protocol: HTTP, HTTPS or TCP")
+	c.Flags().String("description", "", "description")
+	c.Flags().String("method", "", "GET or HEAD for HTTP(S), connection_established for TCP (default GET or connection_established)")
+	c.Flags().String("path", "/", "path to request (HTTP and HTTPS)")
+	c.Flags().Int64("port", 0, "port to connect to, 0 for the default of the protocol")
+	c.Flags().StringArray("header", nil, "request header as \"Name: value\" (HTTP and HTTPS, repeatable)")
+	c.Flags().StringSlice("expected-codes", []string{"200"}, "expected response codes, e.g. 200,2xx (HTTP and HTTPS)")
+	c.Flags().String("expected-body", "", "substring the response body must contain (HTTP and HTTPS)")
+	c.Flags().Bool("follow-redirects", false, "follow redirects (HTTP and HTTPS)")
+	c.Flags().Bool("allow-insecure", false, "do not validate the certificate (HTTPS)")
+	c.Flags().Int64("interval", 60, "seconds between checks")
+	c.Flags().Int64("timeout", 5, "seconds before a check fails")
+	c.Flags().Int64("retries", 2, "immediate retries after a failed check")
+	c.Flags().Int64("consecutive-fails", 1, "failed checks needed to mark the origin unhealthy")
+	c.Flags().Int64("consecutive-successes", 1, "successful checks needed to mark the origin healthy")
+	c.Flags().StringSlice("check-region", nil, "regions to check from, e.g. WNAM,WEU or ALL_REGIONS")
+	c.Flags().Bool("suspended", false, "create or keep the health check suspended")
+}
+
+// healthcheckSpec holds the settings of a health check, so that create,
+// update and preview can share the flag handling.
+type healthcheckSpec struct {
+	Name                 string
+	Address              string
+	Type                 string
+	Description          string
+	Method               string
+	Path                 string
+	Port                 int64
+	Header               map[string][]string
+	ExpectedCodes        []string
+	ExpectedBody         string
+	FollowRedirects      bool
+	AllowInsecure        bool
+	Interval             int64
+	Timeout              int64
+	Retries              int64
+	ConsecutiveFails     int64
+	ConsecutiveSuccesses int64
+	CheckRegions         []string
+	Suspended            bool
+}
+
+func healthcheckSpecFrom(h healthchecks.Healthcheck) healthcheckSpec {
+	s := healthcheckSpec{
+		Name:                 h.Name,
+		Address:              h.Address,
+		Type:                 h.Type,
+		Description:          h.Description,
+		Interval:             h.Interval,
+		Timeout:              h.Timeout,
+		Retries:              h.Retries,
+		ConsecutiveFails:     h.ConsecutiveFails,
+		ConsecutiveSuccesses: h.ConsecutiveSuccesses,
+		Suspended:            h.Suspended,
+	}
+	for _, r := range h.CheckRegions {
+		s.CheckRegions = append(s.CheckRegions, string(r))
+	}
+	if strings.EqualFold(h.Type, "TCP") {
+		s.Method = string(h.TCPConfig.Method)
+		s.Port = h.TCPConfig.Port
+	} else {
+		s.Method = string(h.HTTPConfig.Method)
+		s.Path = h.HTTPConfig.Path
+		s.Port = h.HTTPConfig.Port
+		s.Header = h.HTTPConfig.Header
+		s.ExpectedCodes = h.HTTPConfig.ExpectedCodes
+		s.ExpectedBody = h.HTTPConfig.ExpectedBody
+		s.FollowRedirects = h.HTTPConfig.FollowRedirects
+		s.AllowInsecure = h.HTTPConfig.AllowInsecure
+	}
+	return s
+}
+
+// applyFlags sets the flags that were given, or all of them when all is set.
+func (s *healthcheckSpec) applyFlags(c *cobra.Command, all bool) error {
+	set := func(flag string) bool { return all || c.Flags().Changed(flag) }
+
+	for flag, p := range map[string]*string{
+		"name": &s.Name, "address": &s.Address, "type": &s.Type, "description": &s.Description,
+		"method": &s.Method, "path": &s.Path, "expected-body": &s.ExpectedBody,
+	} {
+		if set(flag) {
+			*p, _ = c.Flags().GetString(flag)
+		}
+	}
+	for flag, p := range map[string]*int64{
+		"port": &s.Port, "interval": &s.Interval, "timeout": &s.Timeout, "retries": &s.Retries,
+		"consecutive-fails": &s.ConsecutiveFails, "consecutive-successes": &s.ConsecutiveSuccesses,
+	} {
+		if set(flag) {
+			*p, _ = c.Flags().GetInt64(flag)
+		}
+	}
+	for flag, p := range map[string]*bool{
+		"follow-redirects": &s.FollowRedirects, "allow-insecure": &s.AllowInsecure, "suspended": &s.Suspended,
+	} {
+		if set(flag) {
+			*p, _ = c.Flags().GetBool(flag)
+		}
+	}
+	for flag, p := range map[string]*[]string{"expected-codes": &s.ExpectedCodes, "check-region": &s.CheckRegions} {
+		if set(flag) {
+			*p, _ = c.Flags().GetStringSlice(flag)
+		}
+	}
+	if set("header") {
+		headers, _ := c.Flags().GetStringArray("header")
+		var err error
+		if s.Header, err = parseHeaderFlags(headers); err != nil {
+			return err
+		}
+	}
+	// A method of the old protocol does not carry over to a new one.
+	if !all && c.Flags().Changed("type") && !c.Flags().Changed("method") {
+		s.Method = ""
+	}
+	return nil
+}
+
+func (s healthcheckSpec) tcp() bool {
+	return s.Type == "TCP"
+}
+
+// normalize fills in defaults and checks the settings.
+func (s *healthcheckSpec) normalize() error {
+	s.Type = strings.ToUpper(s.Type)
+	switch s.Type {
+	case "HTTP", "HTTPS":
+		if s.Method == "" {
+			s.Method = "GET"
+		}
+		s.Method = strings.ToUpper(s.Method)
+		if !healthchecks.HTTPConfigurationMethod(s.Method).IsKnown() {
+			return fmt.Errorf("invalid method %q for %s health checks: must be GET or HEAD", s.Method, s.Type)
+		}
+		if len(s.ExpectedCodes) == 0 {
+			return fmt.Errorf("--expected-codes is required for %s health checks", s.Type)
+		}
+	case "TCP":
+		if s.Method == "" {
+			s.Method = "connection_established"
+		}
+		if !healthchecks.TCPConfigurationMethod(s.Method).IsKnown() {
+			return fmt.Errorf("invalid method %q for TCP health checks: must be connection_established", s.Method)
+		}
+	default:
+		return fmt.Errorf("invalid type %q: must be HTTP, HTTPS or TCP", s.Type)
+	}
+	if s.Name == "" {
+		return errors.New("--name is required")
+	}
+	if s.Address == "" {
+		return errors.New("--address is required")
+	}
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("invalid port %d", s.Port)
+	}
+	if s.Interval < 5 || s.Interval > 3600 {
+		return fmt.Errorf("invalid interval %d: must be between 5 and 3600 seconds", s.Interval)
+	}
+	if s.Timeout < 1 || s.Timeout >= s.Interval {
+		return fmt.Errorf("invalid timeout %d: must be at least 1 second and shorter than the interval", s.Timeout)
+	}
+	if s.Retries < 0 || s.Retries > 5 {
+		return fmt.Errorf("invalid retries %d: must be between 0 and 5", s.Retries)
+	}
+	if s.ConsecutiveFails < 1 || s.ConsecutiveSuccesses < 1 {
+		return errors.New("--consecutive-fails and --consecutive-successes must be at least 1")
+	}
+	for i, r := range s.CheckRegions {
+		s.CheckRegions[i] = strings.ToUpper(strings.TrimSpace(r))
+		if !healthchecks.CheckRegion(s.CheckRegions[i]).IsKnown() {
+			return fmt.Errorf("invalid check region %q", r)
+		}
+	}
+	return nil
+}
+
+func (s healthcheckSpec) param() healthchecks.QueryHealthcheckParam {
+	p := healthchecks.QueryHealthcheckParam{
+		Name:                 cloudflare.F(s.Name),
+		Address:              cloudflare.F(s.Address),
+		Type:                 cloudflare.F(s.Type),
+		Description:          cloudflare.F(s.Description),
+		Interval:             cloudflare.F(s.Interval),
+		Timeout:              cloudflare.F(s.Timeout),
+		Retries:              cloudflare.F(s.Retries),
+		ConsecutiveFails:     cloudflare.F(s.ConsecutiveFails),
+		ConsecutiveSuccesses: cloudflare.F(s.ConsecutiveSuccesses),
+		Suspended:            cloudflare.F(s.Suspended),
+	}
+	if len(s.CheckRegions) > 0 {
+		regions := make([]healthchecks.CheckRegion, 0, len(s.CheckRegions))
+		for _, r := range s.CheckRegions {
+			regions = append(regions, healthchecks.CheckRegion(r))
+		}
+		p.CheckRegions = cloudflare.F(regions)
+	}
+	if s.tcp() {
+		tcp := healthchecks.TCPConfigurationParam{Method: cloudflare.F(healthchecks.TCPConfigurationMethod(s.Method))}
+		if s.Port != 0 {
+			tcp.Port = cloudflare.F(s.Port)
+		}
+		p.TCPConfig = cloudflare.F(tcp)
+		return p
+	}
+	http := healthchecks.HTTPConfigurationParam{
+		Method:          cloudflare.F(healthchecks.HTTPConfigurationMethod(s.Method)),
+		Path:            cloudflare.F(s.Path),
+		ExpectedCodes:   cloudflare.F(s.ExpectedCodes),
+		ExpectedBody:    cloudflare.F(s.ExpectedBody),
+		FollowRedirects: cloudflare.F(s.FollowRedirects),
+		AllowInsecure:   cloudflare.F(s.AllowInsecure),
+	}
+	if s.Port != 0 {
+		http.Port = cloudflare.F(s.Port)
+	}
+	if len(s.Header) > 0 {
+		http.Header = cloudflare.F(s.Header)
+	}
+	p.HTTPConfig = cloudflare.F(http)
+	return p
+}
+
+func formatHealthcheck(h healthchecks.Healthcheck) []string {
+	target := h.Address
+	port := h.HTTPConfig.Port
+	if strings.EqualFold(h.Type, "TCP") {
+		port = h.TCPConfig.Port
+	}
+	if port != 0 {
+		target += ":" + strconv.FormatInt(port, 10)
+	}
+	if !strings.EqualFold(h.Type, "TCP") {
+		target += h.HTTPConfig.Path
+	}
+	regions := make([]string, 0, len(h.CheckRegions))
+	for _, r := range h.CheckRegions {
+		regions = append(regions, string(r))
+	}
+	return []string{
+		h.ID,
+		h.Name,
+		h.Type,
+		target,
+		strings.Join(h.HTTPConfig.ExpectedCodes, ","),
+		strconv.FormatInt(h.Interval, 10),
+		strconv.FormatInt(h.Retries, 10),
+		strings.Join(regions, ","),
+		string(h.Status),
+		h.FailureReason,
+	}
+}
+
+func writeHealthchecks(c *cobra.Command, checks []healthchecks.Healthcheck) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(checks))
+		for _, h := range checks {
+			raw = append(raw, json.RawMessage(h.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(checks))
+	for _, h := range checks {
+		output = append(output, formatHealthcheck(h))
+	}
+	writeTable(output, "ID", "Name", "Type", "Target", "Expected Codes", "Interval", "Retries", "Regions", "Status", "Failure Reason")
+	return nil
+}
+
+// findHealthcheck returns the health check given by --id or --name.
+func findHealthcheck(c *cobra.Command, zoneID string) (healthchecks.Healthcheck, error) {
+	id, _ := c.Flags().GetString("id")
+	name, _ := c.Flags().GetString("name")
+	if id != "" {
+		h, err := client.Healthchecks.Get(c.Context(), id, healthchecks.HealthcheckGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return healthchecks.Healthcheck{}, fmt.Errorf("Error getting health check: %w", err)
+		}
+		return *h, nil
+	}
+	if name == "" {
+		return healthchecks.Healthcheck{}, errors.New("--id or --name is required")
+	}
+
+	var found []healthchecks.Healthcheck
+	iter := client.Healthchecks.ListAutoPaging(c.Context(), healthchecks.HealthcheckListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		if h := iter.Current(); h.Name == name {
+			found = append(found, h)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return healthchecks.Healthcheck{}, fmt.Errorf("Error listing health checks: %w", err)
+	}
+	switch len(found) {
+	case 0:
+		return healthchecks.Healthcheck{}, fmt.Errorf("health check %s not found", name)
+	case 1:
+		return found[0], nil
+	}
+	return healthchecks.Healthcheck{}, fmt.Errorf("more than one health check is named %s; use --id", name)
+}
+
+func healthchecksList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var checks []healthchecks.Healthcheck
+	iter := client.Healthchecks.ListAutoPaging(c.Context(), healthchecks.HealthcheckListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		checks = append(checks, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing health checks: %w", err)
+	}
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return writeHealthchecks(c, checks)
+}
+
+func healthchecksCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "name", "address"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	var spec healthcheckSpec
+	if err := spec.applyFlags(c, true); err != nil {
+		return err
+	}
+	if err := spec.normalize(); err != nil {
+		return err
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	h, err := client.Healthchecks.New(c.Context(), healthchecks.HealthcheckNewParams{
+		ZoneID:           cloudflare.F(zoneID),
+		QueryHealthcheck: spec.param(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating health check: %w", err)
+	}
+	return writeHealthchecks(c, []healthchecks.Healthcheck{*h})
+}
+
+func healthchecksUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	current, err := findHealthcheck(c, zoneID)
+	if err != nil {
+		return err
+	}
+	spec := healthcheckSpecFrom(current)
+	if err := spec.applyFlags(c, false); err != nil {
+		return err
+	}
+	if err := spec.normalize(); err != nil {
+		return err
+	}
+
+	h, err := client.Healthchecks.Update(c.Context(), current.ID, healthchecks.HealthcheckUpdateParams{
+		ZoneID:           cloudflare.F(zoneID),
+		QueryHealthcheck: spec.param(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating health check: %w", err)
+	}
+	return writeHealthchecks(c, []healthchecks.Healthcheck{*h})
+}
+
+func healthchecksDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	h, err := findHealthcheck(c, zoneID)
+	if err != nil {
+		return err
+	}
+	_, err = client.Healthchecks.Delete(c.Context(), h.ID, healthchecks.HealthcheckDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting health check: %w", err)
+	}
+	fmt.Printf("Deleted health check %s (%s)\n", h.Name, h.ID)
+	return nil
+}
+
+func healthchecksPreview(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "address"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	wait, _ := c.Flags().GetDuration("wait")
+	var spec healthcheckSpec
+	if err := spec.applyFlags(c, true); err != nil {
+		return err
+	}
+	if spec.Name == "" {
+		spec.Name = "preview"
+	}
+	if err := spec.normalize(); err != nil {
+		return err
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	h, err := client.Healthchecks.Previews.New(c.Context(), healthchecks.PreviewNewParams{
+		ZoneID:           cloudflare.F(zoneID),
+		QueryHealthcheck: spec.param(),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating health check preview: %w", err)
+	}
+	id := h.ID
+	defer func() {
+		// Clean up even if the wait was interrupted.
+		_, err := client.Healthchecks.Previews.Delete(context.Background(), id, healthchecks.PreviewDeleteParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error deleting health check preview %s: %v\n", id, err)
+		}
+	}()
+
+	deadline := time.Now().Add(wait)
+	for h.Status == healthchecks.HealthcheckStatusUnknown || h.Status == "" {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("no result from the health check preview after %s", wait)
+		}
+		select {
+		case <-c.Context().Done():
+			return c.Context().Err()
+		case <-time.After(healthcheckPreviewPollInterval):
+		}
+		res, err := client.Healthchecks.Previews.Get(c.Context(), id, healthchecks.PreviewGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting health check preview: %w", err)
+		}
+		h = res
+	}
+
+	if err := writeHealthchecks(c, []healthchecks.Healthcheck{*h}); err != nil {
+		return err
+	}
+	if h.Status == healthchecks.HealthcheckStatusUnhealthy {
+		c.SilenceUsage = true
+		return &exitError{code: 2, msg: fmt.Sprintf("%s is unhealthy: %s", spec.Address, h.FailureReason)}
+	}
+	return nil
+}
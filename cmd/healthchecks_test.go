@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v6/healthchecks"
+)
+
+func TestHealthcheckSpecNormalize(t *testing.T) {
+	valid := healthcheckSpec{
+		Name: "origin", Address: "origin.example.com", Type: "https", Path: "/health",
+		ExpectedCodes: []string{"2xx"}, Interval: 60, Timeout: 5, Retries: 2,
+		ConsecutiveFails: 1, ConsecutiveSuccesses: 1, CheckRegions: []string{"weu", "ENAM"},
+	}
+	s := valid
+	s.CheckRegions = append([]string(nil), valid.CheckRegions...)
+	if err := s.normalize(); err != nil {
+		t.Fatalf("normalize(valid) = %v", err)
+	}
+	if s.Type != "HTTPS" || s.Method != "GET" || s.CheckRegions[0] != "WEU" {
+		t.Errorf("normalize(valid) = %+v", s)
+	}
+
+	tcp := healthcheckSpec{Name: "db", Address: "192.0.2.5", Type: "tcp", Port: 5432, Interval: 30, Timeout: 5, ConsecutiveFails: 1, ConsecutiveSuccesses: 1}
+	if err := tcp.normalize(); err != nil {
+		t.Fatalf("normalize(tcp) = %v", err)
+	}
+	p := tcp.param()
+	if tcp.Method != "connection_established" || p.HTTPConfig.Present || p.TCPConfig.Value.Port.Value != 5432 {
+		t.Errorf("param(tcp) = %+v", p)
+	}
+
+	for name, change := range map[string]func(s *healthcheckSpec){
+		"type":     func(s *healthcheckSpec) { s.Type = "UDP" },
+		"method":   func(s *healthcheckSpec) { s.Method = "POST" },
+		"codes":    func(s *healthcheckSpec) { s.ExpectedCodes = nil },
+		"address":  func(s *healthcheckSpec) { s.Address = "" },
+		"interval": func(s *healthcheckSpec) { s.Interval = 4000 },
+		"timeout":  func(s *healthcheckSpec) { s.Timeout = 60 },
+		"retries":  func(s *healthcheckSpec) { s.Retries = -1 },
+		"fails":    func(s *healthcheckSpec) { s.ConsecutiveFails = 0 },
+		"region":   func(s *healthcheckSpec) { s.CheckRegions = []string{"MARS"} },
+	} {
+		s := valid
+		s.CheckRegions = append([]string(nil), valid.CheckRegions...)
+		change(&s)
+		if err := s.normalize(); err == nil {
+			t.Errorf("normalize(%s) = nil error; want error", name)
+		}
+	}
+}
+
+func TestHealthcheckSpecFrom(t *testing.T) {
+	h := healthchecks.Healthcheck{
+		Name:    "origin",
+		Address: "origin.example.com",
+		Type:    "HTTP",
+		HTTPConfig: healthchecks.HTTPConfiguration{
+			Method:        healthchecks.HTTPConfigurationMethodHead,
+			Path:          "/status",
+			ExpectedCodes: []string{"200", "204"},
+		},
+		Interval: 60, Timeout: 5, ConsecutiveFails: 2, ConsecutiveSuccesses: 1,
+	}
+	s := healthcheckSpecFrom(h)
+	if err := s.normalize(); err != nil {
+		t.Fatal(err)
+	}
+	p := s.param()
+	if p.HTTPConfig.Value.Method.Value != healthchecks.HTTPConfigurationMethodHead || p.HTTPConfig.Value.Path.Value != "/status" ||
+		len(p.HTTPConfig.Value.ExpectedCodes.Value) != 2 || p.TCPConfig.Present {
+		t.Errorf("param() = %+v", p)
+	}
+}
@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/load_balancers"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var lbCmd = &cobra.Command{
+	Use:     "lb",
+	Aliases: []string{"load-balancing"},
+	Short:   "Load balancers, pools and monitors",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var lbMonitorsCmd = &cobra.Command{
+	Use:   "monitors",
+	Short: "Health monitors of an account",
+}
+
+var lbMonitorsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List monitors",
+	RunE:    lbMonitorsList,
+}
+
+var lbMonitorsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a monitor",
+	RunE:  lbMonitorsCreate,
+}
+
+var lbMonitorsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a monitor",
+	RunE:  lbMonitorsUpdate,
+}
+
+var lbMonitorsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a monitor",
+	RunE:  lbMonitorsDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(lbCmd)
+	lbCmd.AddCommand(lbMonitorsCmd)
+	lbMonitorsCmd.AddCommand(lbMonitorsListCmd)
+	lbMonitorsCmd.AddCommand(lbMonitorsCreateCmd)
+	lbMonitorsCmd.AddCommand(lbMonitorsUpdateCmd)
+	lbMonitorsCmd.AddCommand(lbMonitorsDeleteCmd)
+
+	lbMonitorsCmd.PersistentFlags().String("account", "", "account name or ID")
+
+	addLBMonitorFlags(lbMonitorsCreateCmd)
+	addLBMonitorFlags(lbMonitorsUpdateCmd)
+	lbMonitorsUpdateCmd.Flags().String("id", "", "monitor ID")
+
+	lbMonitorsDeleteCmd.Flags().String("id", "", "monitor ID")
+}
+
+func addLBMonitorFlags(c *cobra.Command) {
+	c.Flags().String("type", "http", "protocol: http, https, tcp, udp_icmp, icmp_ping or smtp")
+	c.Flags().String("description", "", "description")
+	c.Flags().String("method", "GET", "HTTP method (http and https)")
+	c.Flags().String("path", "/", "path to request (http and https)")
+	c.Flags().StringArray("header", nil, "request header as \"Name: value\" (http and https, repeatable)")
+	c.Flags().String("expected-codes", "200", "expected response codes, e.g. 200 or 2xx (http and https)")
+	c.Flags().String("expected-body", "", "substring the response body must contain (http and https)")
+	c.Flags().Bool("follow-redirects", false, "follow redirects (http and https)")
+	c.Flags().Bool("allow-insecure", false, "do not validate the certificate (https)")
+	c.Flags().Int64("port", 0, "port to connect to, 0 for the default of the protocol")
+	c.Flags().Int64("interval", 60, "seconds between checks")
+	c.Flags().Int64("timeout", 5, "seconds before a check fails")
+	c.Flags().Int64("retries", 2, "retries after a failed check before marking the origin unhealthy")
+	c.Flags().Int64("consecutive-up", 0, "successful checks needed to mark an origin healthy")
+	c.Flags().Int64("consecutive-down", 0, "failed checks needed to mark an origin unhealthy")
+	c.Flags().String("probe-zone", "", "zone name to send in the Host header and for Cloudflare-specific features")
+}
+
+// lbMonitorSpec holds the settings of a monitor, so that create and update
+// can share the flag handling.
+type lbMonitorSpec struct {
+	Type            string
+	Description     string
+	Method          string
+	Path            string
+	Header          map[string][]string
+	ExpectedCodes   string
+	ExpectedBody    string
+	FollowRedirects bool
+	AllowInsecure   bool
+	Port            int64
+	Interval        int64
+	Timeout         int64
+	Retries         int64
+	ConsecutiveUp   int64
+	ConsecutiveDown int64
+	ProbeZone       string
+}
+
+func lbMonitorSpecFrom(m load_balancers.Monitor) lbMonitorSpec {
+	return lbMonitorSpec{
+		Type:            string(m.Type),
+		Description:     m.Description,
+		Method:          m.Method,
+		Path:            m.Path,
+		Header:          m.Header,
+		ExpectedCodes:   m.ExpectedCodes,
+		ExpectedBody:    m.ExpectedBody,
+		FollowRedirects: m.FollowRedirects,
+		AllowInsecure:   m.AllowInsecure,
+		Port:            m.Port,
+		Interval:        m.Interval,
+		Timeout:         m.Timeout,
+		Retries:         m.Retries,
+		ConsecutiveUp:   m.ConsecutiveUp,
+		ConsecutiveDown: m.ConsecutiveDown,
+		ProbeZone:       m.ProbeZone,
+	}
+}
+
+// parseHeaderFlags parses "Name: value" headers into the map the monitor API
+// uses.
+func parseHeaderFlags(headers []string) (map[string][]string, error) {
+	m := map[string][]string{}
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		name = strings.TrimSpace(name)
+		if !ok || !validHeaderName(name) {
+			return nil, fmt.Errorf("invalid header %q: must be \"Name: value\"", h)
+		}
+		m[name] = append(m[name], strings.TrimSpace(value))
+	}
+	return m, nil
+}
+
+// applyFlags sets the flags that were given, or all of them when all is set.
+func (s *lbMonitorSpec) applyFlags(c *cobra.Command, all bool) error {
+	set := func(flag string) bool { return all || c.Flags().Changed(flag) }
+
+	for flag, p := range map[string]*string{
+		"type": &s.Type, "description": &s.Description, "method": &s.Method, "path": &s.Path,
+		"expected-codes": &s.ExpectedCodes, "expected-body": &s.ExpectedBody, "probe-zone": &s.ProbeZone,
+	} {
+		if set(flag) {
+			*p, _ = c.Flags().GetString(flag)
+		}
+	}
+	for flag, p := range map[string]*int64{
+		"port": &s.Port, "interval": &s.Interval, "timeout": &s.Timeout, "retries": &s.Retries,
+		"consecutive-up": &s.ConsecutiveUp, "consecutive-down": &s.ConsecutiveDown,
+	} {
+		if set(flag) {
+			*p, _ = c.Flags().GetInt64(flag)
+		}
+	}
+	for flag, p := range map[string]*bool{"follow-redirects": &s.FollowRedirects, "allow-insecure": &s.AllowInsecure} {
+		if set(flag) {
+			*p, _ = c.Flags().GetBool(flag)
+		}
+	}
+	if set("header") {
+		headers, _ := c.Flags().GetStringArray("header")
+		var err error
+		if s.Header, err = parseHeaderFlags(headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// http reports whether the monitor makes HTTP requests.
+func (s lbMonitorSpec) http() bool {
+	return s.Type == "http" || s.Type == "https"
+}
+
+func (s lbMonitorSpec) validate() error {
+	if !load_balancers.MonitorNewParamsType(s.Type).IsKnown() {
+		return fmt.Errorf("invalid monitor type %q: must be http, https, tcp, udp_icmp, icmp_ping or smtp", s.Type)
+	}
+	if s.http() && s.ExpectedCodes == "" {
+		return fmt.Errorf("--expected-codes is required for %s monitors", s.Type)
+	}
+	if s.Interval < 5 || s.Interval > 3600 {
+		return fmt.Errorf("invalid interval %d: must be between 5 and 3600 seconds", s.Interval)
+	}
+	if s.Timeout < 1 || s.Timeout > 10 {
+		return fmt.Errorf("invalid timeout %d: must be between 1 and 10 seconds", s.Timeout)
+	}
+	if s.Timeout >= s.Interval {
+		return fmt.Errorf("the timeout (%ds) must be shorter than the interval (%ds)", s.Timeout, s.Interval)
+	}
+	if s.Retries < 0 || s.Retries > 5 {
+		return fmt.Errorf("invalid retries %d: must be between 0 and 5", s.Retries)
+	}
+	if s.Port < 0 || s.Port > 65535 {
+		return fmt.Errorf("invalid port %d", s.Port)
+	}
+	if !s.http() && s.Type != "tcp" && s.Port != 0 {
+		return fmt.Errorf("--port cannot be used with %s monitors", s.Type)
+	}
+	return nil
+}
+
+func (s lbMonitorSpec) newParams(accountID string) load_balancers.MonitorNewParams {
+	params := load_balancers.MonitorNewParams{
+		AccountID:       cloudflare.F(accountID),
+		Type:            cloudflare.F(load_balancers.MonitorNewParamsType(s.Type)),
+		Description:     cloudflare.F(s.Description),
+		Interval:        cloudflare.F(s.Interval),
+		Timeout:         cloudflare.F(s.Timeout),
+		Retries:         cloudflare.F(s.Retries),
+		ConsecutiveUp:   cloudflare.F(s.ConsecutiveUp),
+		ConsecutiveDown: cloudflare.F(s.ConsecutiveDown),
+	}
+	if s.Port != 0 {
+		params.Port = cloudflare.F(s.Port)
+	}
+	if s.ProbeZone != "" {
+		params.ProbeZone = cloudflare.F(s.ProbeZone)
+	}
+	if s.http() {
+		params.Method = cloudflare.F(s.Method)
+		params.Path = cloudflare.F(s.Path)
+		params.ExpectedCodes = cloudflare.F(s.ExpectedCodes)
+		params.ExpectedBody = cloudflare.F(s.ExpectedBody)
+		params.FollowRedirects = cloudflare.F(s.FollowRedirects)
+		params.AllowInsecure = cloudflare.F(s.AllowInsecure)
+		if len(s.Header) > 0 {
+			params.Header = cloudflare.F(s.Header)
+		}
+	}
+	return params
+}
+
+func (s lbMonitorSpec) editParams(accountID string) load_balancers.MonitorEditParams {
+	params := load_balancers.MonitorEditParams{
+		AccountID:       cloudflare.F(accountID),
+		Type:            cloudflare.F(load_balancers.MonitorEditParamsType(s.Type)),
+		Description:     cloudflare.F(s.Description),
+		Interval:        cloudflare.F(s.Interval),
+		Timeout:         cloudflare.F(s.Timeout),
+		Retries:         cloudflare.F(s.Retries),
+		ConsecutiveUp:   cloudflare.F(s.ConsecutiveUp),
+		ConsecutiveDown: cloudflare.F(s.ConsecutiveDown),
+		Port:            cloudflare.F(s.Port),
+	}
+	if s.ProbeZone != "" {
+		params.ProbeZone = cloudflare.F(s.ProbeZone)
+	}
+	if s.http() {
+		params.Method = cloudflare.F(s.Method)
+		params.Path = cloudflare.F(s.Path)
+		params.ExpectedCodes = cloudflare.F(s.ExpectedCodes)
+		params.ExpectedBody = cloudflare.F(s.ExpectedBody)
+		params.FollowRedirects = cloudflare.F(s.FollowRedirects)
+		params.AllowInsecure = cloudflare.F(s.AllowInsecure)
+		params.Header = cloudflare.F(s.Header)
+	}
+	return params
+}
+
+func formatLBMonitor(m load_balancers.Monitor) []string {
+	target := m.Path
+	if m.Port != 0 {
+		target = strconv.FormatInt(m.Port, 10) + target
+		if m.Path != "" {
+			target = ":" + target
+		}
+	}
+	return []string{
+		m.ID,
+		string(m.Type),
+		m.Description,
+		target,
+		m.ExpectedCodes,
+		strconv.FormatInt(m.Interval, 10),
+		strconv.FormatInt(m.Timeout, 10),
+		strconv.FormatInt(m.Retries, 10),
+	}
+}
+
+func writeLBMonitors(c *cobra.Command, monitors []load_balancers.Monitor) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(monitors))
+		for _, m := range monitors {
+			raw = append(raw, json.RawMessage(m.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(monitors))
+	for _, m := range monitors {
+		output = append(output, formatLBMonitor(m))
+	}
+	writeTable(output, "ID", "Type", "Description", "Target", "Expected Codes", "Interval", "Timeout", "Retries")
+	return nil
+}
+
+func lbMonitorsList(c *cobra.Command, args []string) error {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	var monitors []load_balancers.Monitor
+	iter := client.LoadBalancers.Monitors.ListAutoPaging(c.Context(), load_balancers.MonitorListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		monitors = append(monitors, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing monitors: %w", err)
+	}
+	sort.SliceStable(monitors, func(i, j int) bool { return monitors[i].Description < monitors[j].Description })
+	return writeLBMonitors(c, monitors)
+}
+
+func lbMonitorsCreate(c *cobra.Command, args []string) error {
+	var spec lbMonitorSpec
+	if err := spec.applyFlags(c, true); err != nil {
+		return err
+	}
+	if err := spec.validate(); err != nil {
+		return err
+	}
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	m, err := client.LoadBalancers.Monitors.New(c.Context(), spec.newParams(accountID))
+	if err != nil {
+		return fmt.Errorf("Error creating monitor: %w", err)
+	}
+	return writeLBMonitors(c, []load_balancers.Monitor{*m})
+}
+
+func lbMonitorsUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	current, err := client.LoadBalancers.Monitors.Get(c.Context(), id, load_balancers.MonitorGetParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting monitor: %w", err)
+	}
+	spec := lbMonitorSpecFrom(*current)
+	if err := spec.applyFlags(c, false); err != nil {
+		return err
+	}
+	if err := spec.validate(); err != nil {
+		return err
+	}
+
+	m, err := client.LoadBalancers.Monitors.Edit(c.Context(), id, spec.editParams(accountID))
+	if err != nil {
+		return fmt.Errorf("Error updating monitor: %w", err)
+	}
+	return writeLBMonitors(c, []load_balancers.Monitor{*m})
+}
+
+func lbMonitorsDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.LoadBalancers.Monitors.Delete(c.Context(), id, load_balancers.MonitorDeleteParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting monitor: %w", err)
+	}
+	fmt.Printf("Deleted monitor %s\n", id)
+	return nil
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/load_balancers"
+	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var lbBalancersCmd = &cobra.Command{
+	Use:   "balancers",
+	Short: "Load balancers of a zone",
+}
+
+var lbBalancersListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List load balancers",
+	RunE:    lbBalancersList,
+}
+
+var lbBalancersGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a load balancer",
+	RunE:  lbBalancersGet,
+}
+
+var lbBalancersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a load balancer",
+	Long: `Create a load balancer for a hostname of the zone. Pools are given by name
+or ID; the fallback pool defaults to the last default pool.`,
+	RunE: lbBalancersCreate,
+}
+
+var lbBalancersUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a load balancer",
+	RunE:  lbBalancersUpdate,
+}
+
+var lbBalancersDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a load balancer",
+	RunE:  lbBalancersDelete,
+}
+
+func init() {
+	lbCmd.AddCommand(lbBalancersCmd)
+	lbBalancersCmd.AddCommand(lbBalancersListCmd)
+	lbBalancersCmd.AddCommand(lbBalancersGetCmd)
+	lbBalancersCmd.AddCommand(lbBalancersCreateCmd)
+	lbBalancersCmd.AddCommand(lbBalancersUpdateCmd)
+	lbBalancersCmd.AddCommand(lbBalancersDeleteCmd)
+
+	lbBalancersCmd.PersistentFlags().String("zone", "", "zone name")
+
+	for _, c := range []*cobra.Command{lbBalancersGetCmd, lbBalancersUpdateCmd, lbBalancersDeleteCmd} {
+		c.Flags().String("id", "", "load balancer ID")
+		c.Flags().String("name", "", "hostname of the load balancer")
+	}
+	lbBalancersCreateCmd.Flags().String("name", "", "hostname of the load balancer")
+	addLBBalancerFlags(lbBalancersCreateCmd)
+	addLBBalancerFlags(lbBalancersUpdateCmd)
+	lbBalancersUpdateCmd.Flags().Bool("enabled", true, "whether the load balancer is enabled")
+}
+
+func addLBBalancerFlags(c *cobra.Command) {
+	c.Flags().StringSlice("default-pool", nil, "pools in failover order, by name or ID (repeatable)")
+	c.Flags().String("fallback-pool", "", "pool to use when all others are unhealthy, by name or ID")
+	c.Flags().Bool("proxied", true, "proxy the traffic through Cloudflare")
+	c.Flags().Float64("ttl", 30, "DNS TTL when not proxied")
+	c.Flags().String("steering-policy", "", "off, geo, random, dynamic_latency, proximity, least_outstanding_requests or least_connections")
+	c.Flags().String("session-affinity", "", "none, cookie, ip_cookie or header")
+	c.Flags().String("description", "", "description")
+}
+
+// lbBalancerSettings holds the flags shared by create and update.
+type lbBalancerSettings struct {
+	steering load_balancers.SteeringPolicy
+	affinity load_balancers.SessionAffinity
+}
+
+func lbBalancerSettingsFrom(c *cobra.Command) (lbBalancerSettings, error) {
+	var s lbBalancerSettings
+	steering, _ := c.Flags().GetString("steering-policy")
+	affinity, _ := c.Flags().GetString("session-affinity")
+	s.steering = load_balancers.SteeringPolicy(steering)
+	if steering != "" && !s.steering.IsKnown() {
+		return s, fmt.Errorf("invalid steering policy %q", steering)
+	}
+	s.affinity = load_balancers.SessionAffinity(affinity)
+	if affinity != "" && !s.affinity.IsKnown() {
+		return s, fmt.Errorf("invalid session affinity %q: must be none, cookie, ip_cookie or header", affinity)
+	}
+	return s, nil
+}
+
+// lbPoolIDs maps pool names or IDs to pool IDs. Pools belong to the account of
+// the zone.
+func lbPoolIDs(c *cobra.Command, zoneID string, refs ...string) ([]string, error) {
+	zone, err := client.Zones.Get(c.Context(), zones.ZoneGetParams{ZoneID: cloudflare.F(zoneID)})
+	if err != nil {
+		return nil, fmt.Errorf("Error getting zone: %w", err)
+	}
+
+	byName := map[string][]string{}
+	byID := map[string]bool{}
+	iter := client.LoadBalancers.Pools.ListAutoPaging(c.Context(), load_balancers.PoolListParams{
+		AccountID: cloudflare.F(zone.Account.ID),
+	})
+	for iter.Next() {
+		p := iter.Current()
+		byID[p.ID] = true
+		byName[p.Name] = append(byName[p.Name], p.ID)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("Error listing pools: %w", err)
+	}
+
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		switch {
+		case byID[ref]:
+			ids = append(ids, ref)
+		case len(byName[ref]) == 1:
+			ids = append(ids, byName[ref][0])
+		case len(byName[ref]) > 1:
+			return nil, fmt.Errorf("more than one pool is named %s; use the pool ID", ref)
+		default:
+			return nil, fmt.Errorf("pool %s not found", ref)
+		}
+	}
+	return ids, nil
+}
+
+// findLoadBalancer returns the load balancer given by --id or --name.
+func findLoadBalancer(c *cobra.Command, zoneID string) (load_balancers.LoadBalancer, error) {
+	id, _ := c.Flags().GetString("id")
+	name, _ := c.Flags().GetString("name")
+	if (id == "") == (name == "") {
+		return load_balancers.LoadBalancer{}, errors.New("exactly one of --id and --name is required")
+	}
+
+	iter := client.LoadBalancers.ListAutoPaging(c.Context(), load_balancers.LoadBalancerListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		lb := iter.Current()
+		if lb.ID == id || (name != "" && strings.EqualFold(lb.Name, name)) {
+			return lb, nil
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return load_balancers.LoadBalancer{}, fmt.Errorf("Error listing load balancers: %w", err)
+	}
+	if id != "" {
+		return load_balancers.LoadBalancer{}, fmt.Errorf("load balancer %s not found", id)
+	}
+	return load_balancers.LoadBalancer{}, fmt.Errorf("load balancer %s not found", name)
+}
+
+func writeLBBalancers(c *cobra.Command, balancers []load_balancers.LoadBalancer) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(balancers))
+		for _, lb := range balancers {
+			raw = append(raw, json.RawMessage(lb.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(balancers))
+	for _, lb := range balancers {
+		ttl := ""
+		if !lb.Proxied {
+			ttl = strconv.FormatFloat(lb.TTL, 'f', -1, 64)
+		}
+		output = append(output, []string{
+			lb.ID,
+			lb.Name,
+			formatBool(lb.Enabled),
+			formatBool(lb.Proxied),
+			ttl,
+			string(lb.SteeringPolicy),
+			string(lb.SessionAffinity),
+			strings.Join(lb.DefaultPools, " "),
+			lb.FallbackPool,
+		})
+	}
+	writeTable(output, "ID", "Name", "Enabled", "Proxied", "TTL", "Steering", "Session Affinity", "Default Pools", "Fallback Pool")
+	return nil
+}
+
+func lbBalancersList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var balancers []load_balancers.LoadBalancer
+	iter := client.LoadBalancers.ListAutoPaging(c.Context(), load_balancers.LoadBalancerListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		balancers = append(balancers, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing load balancers: %w", err)
+	}
+	sort.SliceStable(balancers, func(i, j int) bool { return balancers[i].Name < balancers[j].Name })
+	return writeLBBalancers(c, balancers)
+}
+
+func lbBalancersGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	lb, err := findLoadBalancer(c, zoneID)
+	if err != nil {
+		return err
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(lb.JSON.RawJSON()))
+	}
+	return writeLBBalancers(c, []load_balancers.LoadBalancer{lb})
+}
+
+func lbBalancersCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "name"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	name, _ := c.Flags().GetString("name")
+	pools, _ := c.Flags().GetStringSlice("default-pool")
+	fallback, _ := c.Flags().GetString("fallback-pool")
+	proxied, _ := c.Flags().GetBool("proxied")
+	ttl, _ := c.Flags().GetFloat64("ttl")
+	description, _ := c.Flags().GetString("description")
+
+	if len(pools) == 0 {
+		return errors.New("at least one --default-pool is required")
+	}
+	if fallback == "" {
+		fallback = pools[len(pools)-1]
+	}
+	settings, err := lbBalancerSettingsFrom(c)
+	if err != nil {
+		return err
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	ids, err := lbPoolIDs(c, zoneID, append(pools, fallback)...)
+	if err != nil {
+		return err
+	}
+
+	params := load_balancers.LoadBalancerNewParams{
+		ZoneID:       cloudflare.F(zoneID),
+		Name:         cloudflare.F(name),
+		DefaultPools: cloudflare.F(ids[:len(pools)]),
+		FallbackPool: cloudflare.F(ids[len(pools)]),
+		Proxied:      cloudflare.F(proxied),
+	}
+	if !proxied {
+		params.TTL = cloudflare.F(ttl)
+	}
+	if settings.steering != "" {
+		params.SteeringPolicy = cloudflare.F(settings.steering)
+	}
+	if settings.affinity != "" {
+		params.SessionAffinity = cloudflare.F(settings.affinity)
+	}
+	if description != "" {
+		params.Description = cloudflare.F(description)
+	}
+
+	lb, err := client.LoadBalancers.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error creating load balancer: %w", err)
+	}
+	return writeLBBalancers(c, []load_balancers.LoadBalancer{*lb})
+}
+
+func lbBalancersUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	settings, err := lbBalancerSettingsFrom(c)
+	if err != nil {
+		return err
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := load_balancers.LoadBalancerEditParams{ZoneID: cloudflare.F(zoneID)}
+	changed := false
+	var refs []string
+	pools, _ := c.Flags().GetStringSlice("default-pool")
+	fallback, _ := c.Flags().GetString("fallback-pool")
+	if c.Flags().Changed("default-pool") {
+		if len(pools) == 0 {
+			return errors.New("a load balancer needs at least one default pool")
+		}
+		refs = append(refs, pools...)
+	}
+	if fallback != "" {
+		refs = append(refs, fallback)
+	}
+	if len(refs) > 0 {
+		ids, err := lbPoolIDs(c, zoneID, refs...)
+		if err != nil {
+			return err
+		}
+		if c.Flags().Changed("default-pool") {
+			params.DefaultPools = cloudflare.F(ids[:len(pools)])
+		}
+		if fallback != "" {
+			params.FallbackPool = cloudflare.F(ids[len(ids)-1])
+		}
+		changed = true
+	}
+	if c.Flags().Changed("proxied") {
+		proxied, _ := c.Flags().GetBool("proxied")
+		params.Proxied = cloudflare.F(proxied)
+		changed = true
+	}
+	if c.Flags().Changed("ttl") {
+		ttl, _ := c.Flags().GetFloat64("ttl")
+		params.TTL = cloudflare.F(ttl)
+		changed = true
+	}
+	if c.Flags().Changed("enabled") {
+		enabled, _ := c.Flags().GetBool("enabled")
+		params.Enabled = cloudflare.F(enabled)
+		changed = true
+	}
+	if c.Flags().Changed("description") {
+		description, _ := c.Flags().GetString("description")
+		params.Description = cloudflare.F(description)
+		changed = true
+	}
+	if settings.steering != "" {
+		params.SteeringPolicy = cloudflare.F(settings.steering)
+		changed = true
+	}
+	if settings.affinity != "" {
+		params.SessionAffinity = cloudflare.F(settings.affinity)
+		changed = true
+	}
+	if !changed {
+		return errors.New("nothing to update")
+	}
+
+	lb, err := findLoadBalancer(c, zoneID)
+	if err != nil {
+		return err
+	}
+	res, err := client.LoadBalancers.Edit(c.Context(), lb.ID, params)
+	if err != nil {
+		return fmt.Errorf("Error updating load balancer: %w", err)
+	}
+	return writeLBBalancers(c, []load_balancers.LoadBalancer{*res})
+}
+
+func lbBalancersDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	lb, err := findLoadBalancer(c, zoneID)
+	if err != nil {
+		return err
+	}
+	_, err = client.LoadBalancers.Delete(c.Context(), lb.ID, load_balancers.LoadBalancerDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting load balancer: %w", err)
+	}
+	fmt.Printf("Deleted load balancer %s (%s)\n", lb.Name, lb.ID)
+	return nil
+}
@@ -0,0 +1,855 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/load_balancers"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var lbPoolsCmd = &cobra.Command{
+	Use:   "pools",
+	Short: "Origin pools of an account",
+}
+
+var lbPoolsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List pools",
+	RunE:    lbPoolsList,
+}
+
+var lbPoolsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a pool and its origins",
+	RunE:  lbPoolsGet,
+}
+
+var lbPoolsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a pool",
+	Long: `Create a pool. Origins are given with --origin as the address followed by
+optional key=value settings:
+
+  --origin 192.0.2.10,name=web1,weight=0.5
+  --origin app.example.com,port=8443,host=app.example.com,enabled=false
+
+The name defaults to the address, the weight to 1 and the origin is enabled
+unless enabled=false is given.`,
+	RunE: lbPoolsCreate,
+}
+
+var lbPoolsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a pool",
+	Long: `Update the settings given on the command line. --origin replaces all the
+origins of the pool; use enable-origin, disable-origin or drain to change a
+single origin.`,
+	RunE: lbPoolsUpdate,
+}
+
+var lbPoolsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a pool",
+	RunE:  lbPoolsDelete,
+}
+
+var lbPoolsEnableOriginCmd = &cobra.Command{
+	Use:   "enable-origin",
+	Short: "Enable an origin of a pool",
+	Long: `Enable an origin of a pool. The origin keeps its weight unless --weight is
+given; a drained origin has weight 0, so --weight is required to put it back.
+drain prints the weight to restore.`,
+	RunE: lbPoolsEnableOrigin,
+}
+
+var lbPoolsDisableOriginCmd = &cobra.Command{
+	Use:   "disable-origin",
+	Short: "Disable an origin of a pool",
+	RunE:  lbPoolsDisableOrigin,
+}
+
+var lbPoolsDrainCmd = &cobra.Command{
+	Use:   "drain",
+	Short: "Stop sending new traffic to an origin",
+	Long: `Set the weight of an origin to 0 so that it gets no new traffic, for
+example before deploying to it. Use enable-origin --weight with the weight
+printed by drain to put it back.
+
+The pool must keep at least its minimum number of origins (and at least one)
+enabled, weighted and healthy, otherwise nothing is changed and the command
+fails; --force skips this check.`,
+	RunE: lbPoolsDrain,
+}
+
+var lbPoolsHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Show the health of the origins of a pool",
+	RunE:  lbPoolsHealth,
+}
+
+func init() {
+	lbCmd.AddCommand(lbPoolsCmd)
+	lbPoolsCmd.AddCommand(lbPoolsListCmd)
+	lbPoolsCmd.AddCommand(lbPoolsGetCmd)
+	lbPoolsCmd.AddCommand(lbPoolsCreateCmd)
+	lbPoolsCmd.AddCommand(lbPoolsUpdateCmd)
+	lbPoolsCmd.AddCommand(lbPoolsDeleteCmd)
+	lbPoolsCmd.AddCommand(lbPoolsEnableOriginCmd)
+	lbPoolsCmd.AddCommand(lbPoolsDisableOriginCmd)
+	lbPoolsCmd.AddCommand(lbPoolsDrainCmd)
+	lbPoolsCmd.AddCommand(lbPoolsHealthCmd)
+
+	lbPoolsCmd.PersistentFlags().String("account", "", "account name or ID")
+
+	lbPoolsListCmd.Flags().String("monitor", "", "only pools using this monitor ID")
+
+	for _, c := range []*cobra.Command{lbPoolsGetCmd, lbPoolsUpdateCmd, lbPoolsDeleteCmd,
+		lbPoolsEnableOriginCmd, lbPoolsDisableOriginCmd, lbPoolsDrainCmd, lbPoolsHealthCmd} {
+		c.Flags().String("pool", "", "pool name or ID")
+	}
+
+	addLBPoolFlags(lbPoolsCreateCmd)
+	addLBPoolFlags(lbPoolsUpdateCmd)
+	lbPoolsUpdateCmd.Flags().String("name", "", "new name of the pool")
+	lbPoolsCreateCmd.Flags().String("name", "", "name of the pool")
+
+	for _, c := range []*cobra.Command{lbPoolsEnableOriginCmd, lbPoolsDisableOriginCmd, lbPoolsDrainCmd} {
+		c.Flags().String("origin", "", "origin name or address")
+	}
+	lbPoolsEnableOriginCmd.Flags().Float64("weight", 0, "weight to set, between 0 and 1")
+	lbPoolsDisableOriginCmd.Flags().Bool("force", false, "disable the origin even if too few healthy origins would be left")
+	lbPoolsDrainCmd.Flags().Bool("force", false, "drain the origin even if too few healthy origins would be left")
+	lbPoolsDrainCmd.Flags().Bool("disable", false, "also disable the origin")
+
+	lbPoolsHealthCmd.Flags().Bool("pops", false, "show the health seen from each data center or region")
+}
+
+func addLBPoolFlags(c *cobra.Command) {
+	c.Flags().StringArray("origin", nil, "origin as address[,name=..][,weight=..][,port=..][,host=..][,enabled=..] (repeatable)")
+	c.Flags().String("monitor", "", "monitor ID")
+	c.Flags().Int64("minimum-origins", 1, "healthy origins needed for the pool to be healthy")
+	c.Flags().StringSlice("check-region", nil, "regions to run health checks from, e.g. WNAM,WEU or ALL_REGIONS")
+	c.Flags().String("notification-email", "", "addresses to notify of health changes, comma separated")
+	c.Flags().String("description", "", "description")
+	c.Flags().Bool("enabled", true, "whether the pool is enabled")
+}
+
+// parseOriginFlag parses an --origin value: the address, followed by
+// key=value settings separated by commas.
+func parseOriginFlag(s string) (load_balancers.Origin, error) {
+	o := load_balancers.Origin{Enabled: true, Weight: 1}
+	for i, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			if i != 0 {
+				return o, fmt.Errorf("invalid origin %q: %q is not key=value", s, part)
+			}
+			key, value = "address", part
+		}
+		var err error
+		switch strings.ToLower(key) {
+		case "address":
+			o.Address = value
+		case "name":
+			o.Name = value
+		case "weight":
+			o.Weight, err = strconv.ParseFloat(value, 64)
+			if err == nil && (o.Weight < 0 || o.Weight > 1) {
+				err = errors.New("must be between 0 and 1")
+			}
+		case "port":
+			o.Port, err = strconv.ParseInt(value, 10, 64)
+			if err == nil && (o.Port < 0 || o.Port > 65535) {
+				err = errors.New("out of range")
+			}
+		case "host":
+			o.Header.Host = []string{value}
+		case "enabled":
+			o.Enabled, err = strconv.ParseBool(value)
+		case "vnet", "virtual_network_id":
+			o.VirtualNetworkID = value
+		default:
+			return o, fmt.Errorf("invalid origin %q: unknown setting %q", s, key)
+		}
+		if err != nil {
+			return o, fmt.Errorf("invalid origin %q: invalid %s %q: %w", s, key, value, err)
+		}
+	}
+	if o.Address == "" {
+		return o, fmt.Errorf("invalid origin %q: missing address", s)
+	}
+	if o.Name == "" {
+		o.Name = o.Address
+	}
+	return o, nil
+}
+
+// parseOriginFlags parses the --origin flags, which must name each origin
+// only once.
+func parseOriginFlags(values []string) ([]load_balancers.Origin, error) {
+	origins := make([]load_balancers.Origin, 0, len(values))
+	seen := map[string]bool{}
+	for _, v := range values {
+		o, err := parseOriginFlag(v)
+		if err != nil {
+			return nil, err
+		}
+		if seen[o.Name] {
+			return nil, fmt.Errorf("origin %s given more than once", o.Name)
+		}
+		seen[o.Name] = true
+		origins = append(origins, o)
+	}
+	return origins, nil
+}
+
+// originParams converts origins for a pool update. The API replaces all the
+// origins of a pool, so every setting of every origin has to be sent back.
+func originParams(origins []load_balancers.Origin) []load_balancers.OriginParam {
+	params := make([]load_balancers.OriginParam, 0, len(origins))
+	for _, o := range origins {
+		p := load_balancers.OriginParam{
+			Address: cloudflare.F(o.Address),
+			Name:    cloudflare.F(o.Name),
+			Enabled: cloudflare.F(o.Enabled),
+			Weight:  cloudflare.F(o.Weight),
+		}
+		if o.Port != 0 {
+			p.Port = cloudflare.F(o.Port)
+		}
+		if len(o.Header.Host) > 0 {
+			p.Header = cloudflare.F(load_balancers.HeaderParam{Host: cloudflare.F(o.Header.Host)})
+		}
+		if o.VirtualNetworkID != "" {
+			p.VirtualNetworkID = cloudflare.F(o.VirtualNetworkID)
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// parseCheckRegions validates the --check-region values.
+func parseCheckRegions(values []string) ([]load_balancers.CheckRegion, error) {
+	regions := make([]load_balancers.CheckRegion, 0, len(values))
+	for _, v := range values {
+		r := load_balancers.CheckRegion(strings.ToUpper(strings.TrimSpace(v)))
+		if !r.IsKnown() {
+			return nil, fmt.Errorf("invalid check region %q", v)
+		}
+		regions = append(regions, r)
+	}
+	return regions, nil
+}
+
+// findOrigin returns the index of the origin with the given name or address.
+func findOrigin(pool load_balancers.Pool, ref string) (int, error) {
+	found := -1
+	for i, o := range pool.Origins {
+		if o.Name == ref || o.Address == ref {
+			if found >= 0 {
+				return -1, fmt.Errorf("%s matches more than one origin of pool %s; use the origin name", ref, pool.Name)
+			}
+			found = i
+		}
+	}
+	if found < 0 {
+		return -1, fmt.Errorf("pool %s has no origin %s", pool.Name, ref)
+	}
+	return found, nil
+}
+
+// findPool returns the pool with the given name or ID.
+func findPool(c *cobra.Command, accountID, ref string) (load_balancers.Pool, error) {
+	var pools []load_balancers.Pool
+	iter := client.LoadBalancers.Pools.ListAutoPaging(c.Context(), load_balancers.PoolListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		p := iter.Current()
+		if p.ID == ref {
+			return p, nil
+		}
+		if p.Name == ref {
+			pools = append(pools, p)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return load_balancers.Pool{}, fmt.Errorf("Error listing pools: %w", err)
+	}
+	switch len(pools) {
+	case 0:
+		return load_balancers.Pool{}, fmt.Errorf("pool %s not found", ref)
+	case 1:
+		return pools[0], nil
+	}
+	return load_balancers.Pool{}, fmt.Errorf("more than one pool is named %s; use the pool ID", ref)
+}
+
+// lbOriginHealth is the health of one origin as seen from one data center or
+// region.
+type lbOriginHealth struct {
+	POP           string `json:"pop"`
+	Address       string `json:"address"`
+	Healthy       bool   `json:"healthy"`
+	RTT           string `json:"rtt"`
+	ResponseCode  int64  `json:"response_code"`
+	FailureReason string `json:"failure_reason"`
+}
+
+// parsePoolHealth reads the origin health from a pool health response. The
+// pop_health object is keyed by data center or region, each holding a list of
+// objects keyed by origin address; a single unkeyed entry is accepted too.
+func parsePoolHealth(raw []byte) ([]lbOriginHealth, error) {
+	type popHealth struct {
+		Origins []map[string]struct {
+			Healthy       bool   `json:"healthy"`
+			RTT           string `json:"rtt"`
+			ResponseCode  int64  `json:"response_code"`
+			FailureReason string `json:"failure_reason"`
+		} `json:"origins"`
+	}
+	var res struct {
+		POPHealth map[string]json.RawMessage `json:"pop_health"`
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("Error reading pool health: %w", err)
+	}
+
+	pops := map[string]json.RawMessage{}
+	if _, ok := res.POPHealth["origins"]; ok {
+		data, _ := json.Marshal(res.POPHealth)
+		pops[""] = data
+	} else {
+		pops = res.POPHealth
+	}
+
+	var health []lbOriginHealth
+	for pop, data := range pops {
+		var ph popHealth
+		if err := json.Unmarshal(data, &ph); err != nil {
+			return nil, fmt.Errorf("Error reading pool health of %s: %w", pop, err)
+		}
+		for _, origins := range ph.Origins {
+			for address, o := range origins {
+				health = append(health, lbOriginHealth{
+					POP:           pop,
+					Address:       address,
+					Healthy:       o.Healthy,
+					RTT:           o.RTT,
+					ResponseCode:  o.ResponseCode,
+					FailureReason: o.FailureReason,
+				})
+			}
+		}
+	}
+	sort.Slice(health, func(i, j int) bool {
+		if health[i].Address != health[j].Address {
+			return health[i].Address < health[j].Address
+		}
+		return health[i].POP < health[j].POP
+	})
+	return health, nil
+}
+
+// originHealthy summarizes the health of an origin by address: an origin is
+// healthy when it is healthy from most of the places checking it. Origins
+// without any health data are left out.
+func originHealthy(health []lbOriginHealth) map[string]bool {
+	up := map[string]int{}
+	total := map[string]int{}
+	for _, h := range health {
+		total[h.Address]++
+		if h.Healthy {
+			up[h.Address]++
+		}
+	}
+	healthy := make(map[string]bool, len(total))
+	for address, n := range total {
+		healthy[address] = up[address]*2 > n
+	}
+	return healthy
+}
+
+// checkPoolCapacity checks that a pool with the given origins keeps at least
+// its minimum number of origins, and at least one, enabled, weighted and not
+// known to be unhealthy.
+func checkPoolCapacity(pool load_balancers.Pool, origins []load_balancers.Origin, healthy map[string]bool) error {
+	min := pool.MinimumOrigins
+	if min < 1 {
+		min = 1
+	}
+	var serving int64
+	for _, o := range origins {
+		if h, ok := healthy[o.Address]; o.Enabled && o.Weight > 0 && (!ok || h) {
+			serving++
+		}
+	}
+	if serving < min {
+		return fmt.Errorf("pool %s would be left with %d healthy origins taking traffic, it needs %d; use --force to go ahead anyway", pool.Name, serving, min)
+	}
+	return nil
+}
+
+func getPoolHealth(c *cobra.Command, accountID, poolID string) ([]lbOriginHealth, json.RawMessage, error) {
+	res, err := client.LoadBalancers.Pools.Health.Get(c.Context(), poolID, load_balancers.PoolHealthGetParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error getting pool health: %w", err)
+	}
+	raw := json.RawMessage(res.JSON.RawJSON())
+	health, err := parsePoolHealth(raw)
+	return health, raw, err
+}
+
+func formatOriginWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+func writeLBPools(c *cobra.Command, pools []load_balancers.Pool) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(pools))
+		for _, p := range pools {
+			raw = append(raw, json.RawMessage(p.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(pools))
+	for _, p := range pools {
+		var enabled int
+		for _, o := range p.Origins {
+			if o.Enabled {
+				enabled++
+			}
+		}
+		output = append(output, []string{
+			p.ID,
+			p.Name,
+			formatBool(p.Enabled),
+			fmt.Sprintf("%d/%d", enabled, len(p.Origins)),
+			strconv.FormatInt(p.MinimumOrigins, 10),
+			p.Monitor,
+			p.Description,
+		})
+	}
+	writeTable(output, "ID", "Name", "Enabled", "Origins Enabled", "Minimum Origins", "Monitor", "Description")
+	return nil
+}
+
+func writeLBPoolOrigins(pool load_balancers.Pool) {
+	output := make([][]string, 0, len(pool.Origins))
+	for _, o := range pool.Origins {
+		port := ""
+		if o.Port != 0 {
+			port = strconv.FormatInt(o.Port, 10)
+		}
+		disabledAt := ""
+		if !o.DisabledAt.IsZero() {
+			disabledAt = o.DisabledAt.Format(time.RFC3339)
+		}
+		output = append(output, []string{
+			o.Name,
+			o.Address,
+			port,
+			strings.Join(o.Header.Host, " "),
+			formatOriginWeight(o.Weight),
+			formatBool(o.Enabled),
+			disabledAt,
+		})
+	}
+	writeTable(output, "Name", "Address", "Port", "Host", "Weight", "Enabled", "Disabled At")
+}
+
+func lbPoolsList(c *cobra.Command, args []string) error {
+	monitor, _ := c.Flags().GetString("monitor")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	params := load_balancers.PoolListParams{AccountID: cloudflare.F(accountID)}
+	if monitor != "" {
+		params.Monitor = cloudflare.F(monitor)
+	}
+	var pools []load_balancers.Pool
+	iter := client.LoadBalancers.Pools.ListAutoPaging(c.Context(), params)
+	for iter.Next() {
+		pools = append(pools, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing pools: %w", err)
+	}
+	sort.SliceStable(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	return writeLBPools(c, pools)
+}
+
+func lbPoolsGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "pool"); err != nil {
+		return err
+	}
+	ref, _ := c.Flags().GetString("pool")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	pool, err := findPool(c, accountID, ref)
+	if err != nil {
+		return err
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(pool.JSON.RawJSON()))
+	}
+	writeLBPools(c, []load_balancers.Pool{pool})
+	fmt.Println()
+	writeLBPoolOrigins(pool)
+	return nil
+}
+
+func lbPoolsCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "name"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("name")
+	originFlags, _ := c.Flags().GetStringArray("origin")
+	monitor, _ := c.Flags().GetString("monitor")
+	minimum, _ := c.Flags().GetInt64("minimum-origins")
+	regionFlags, _ := c.Flags().GetStringSlice("check-region")
+	email, _ := c.Flags().GetString("notification-email")
+	description, _ := c.Flags().GetString("description")
+	enabled, _ := c.Flags().GetBool("enabled")
+
+	if len(originFlags) == 0 {
+		return errors.New("at least one --origin is required")
+	}
+	origins, err := parseOriginFlags(originFlags)
+	if err != nil {
+		return err
+	}
+	if minimum < 1 || minimum > int64(len(origins)) {
+		return fmt.Errorf("invalid --minimum-origins %d: must be between 1 and the number of origins", minimum)
+	}
+	regions, err := parseCheckRegions(regionFlags)
+	if err != nil {
+		return err
+	}
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	params := load_balancers.PoolNewParams{
+		AccountID:      cloudflare.F(accountID),
+		Name:           cloudflare.F(name),
+		Origins:        cloudflare.F(originParams(origins)),
+		MinimumOrigins: cloudflare.F(minimum),
+		Enabled:        cloudflare.F(enabled),
+	}
+	if monitor != "" {
+		params.Monitor = cloudflare.F(monitor)
+	}
+	if email != "" {
+		params.NotificationEmail = cloudflare.F(email)
+	}
+	if description != "" {
+		params.Description = cloudflare.F(description)
+	}
+
+	pool, err := client.LoadBalancers.Pools.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error creating pool: %w", err)
+	}
+	// The check regions can only be set once the pool exists.
+	if len(regions) > 0 {
+		pool, err = client.LoadBalancers.Pools.Edit(c.Context(), pool.ID, load_balancers.PoolEditParams{
+			AccountID:    cloudflare.F(accountID),
+			CheckRegions: cloudflare.F(regions),
+		})
+		if err != nil {
+			return fmt.Errorf("Error setting the check regions of the pool: %w", err)
+		}
+	}
+	return writeLBPools(c, []load_balancers.Pool{*pool})
+}
+
+func lbPoolsUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "pool"); err != nil {
+		return err
+	}
+	ref, _ := c.Flags().GetString("pool")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	params := load_balancers.PoolEditParams{AccountID: cloudflare.F(accountID)}
+	changed := false
+	if c.Flags().Changed("name") {
+		name, _ := c.Flags().GetString("name")
+		params.Name = cloudflare.F(name)
+		changed = true
+	}
+	if c.Flags().Changed("monitor") {
+		monitor, _ := c.Flags().GetString("monitor")
+		params.Monitor = cloudflare.F(monitor)
+		changed = true
+	}
+	if c.Flags().Changed("notification-email") {
+		email, _ := c.Flags().GetString("notification-email")
+		params.NotificationEmail = cloudflare.F(email)
+		changed = true
+	}
+	if c.Flags().Changed("description") {
+		description, _ := c.Flags().GetString("description")
+		params.Description = cloudflare.F(description)
+		changed = true
+	}
+	if c.Flags().Changed("enabled") {
+		enabled, _ := c.Flags().GetBool("enabled")
+		params.Enabled = cloudflare.F(enabled)
+		changed = true
+	}
+	if c.Flags().Changed("minimum-origins") {
+		minimum, _ := c.Flags().GetInt64("minimum-origins")
+		if minimum < 1 {
+			return fmt.Errorf("invalid --minimum-origins %d", minimum)
+		}
+		params.MinimumOrigins = cloudflare.F(minimum)
+		changed = true
+	}
+	if c.Flags().Changed("check-region") {
+		regionFlags, _ := c.Flags().GetStringSlice("check-region")
+		regions, err := parseCheckRegions(regionFlags)
+		if err != nil {
+			return err
+		}
+		params.CheckRegions = cloudflare.F(regions)
+		changed = true
+	}
+	if c.Flags().Changed("origin") {
+		originFlags, _ := c.Flags().GetStringArray("origin")
+		origins, err := parseOriginFlags(originFlags)
+		if err != nil {
+			return err
+		}
+		if len(origins) == 0 {
+			return errors.New("a pool needs at least one origin")
+		}
+		params.Origins = cloudflare.F(originParams(origins))
+		changed = true
+	}
+	if !changed {
+		return errors.New("nothing to update")
+	}
+
+	pool, err := findPool(c, accountID, ref)
+	if err != nil {
+		return err
+	}
+	res, err := client.LoadBalancers.Pools.Edit(c.Context(), pool.ID, params)
+	if err != nil {
+		return fmt.Errorf("Error updating pool: %w", err)
+	}
+	return writeLBPools(c, []load_balancers.Pool{*res})
+}
+
+func lbPoolsDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "pool"); err != nil {
+		return err
+	}
+	ref, _ := c.Flags().GetString("pool")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	pool, err := findPool(c, accountID, ref)
+	if err != nil {
+		return err
+	}
+	_, err = client.LoadBalancers.Pools.Delete(c.Context(), pool.ID, load_balancers.PoolDeleteParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting pool: %w", err)
+	}
+	fmt.Printf("Deleted pool %s (%s)\n", pool.Name, pool.ID)
+	return nil
+}
+
+// updatePoolOrigin changes one origin of a pool. Unless force is set, the
+// change is refused when it would leave the pool without enough healthy
+// origins taking traffic.
+func updatePoolOrigin(c *cobra.Command, force bool, change func(o *load_balancers.Origin) error) error {
+	if err := checkFlags(c, "pool", "origin"); err != nil {
+		return err
+	}
+	ref, _ := c.Flags().GetString("pool")
+	originRef, _ := c.Flags().GetString("origin")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	pool, err := findPool(c, accountID, ref)
+	if err != nil {
+		return err
+	}
+	i, err := findOrigin(pool, originRef)
+	if err != nil {
+		return err
+	}
+	origins := append([]load_balancers.Origin(nil), pool.Origins...)
+	if err := change(&origins[i]); err != nil {
+		return err
+	}
+
+	if !force {
+		// Without health data, for example for a pool without a monitor,
+		// the health of the origins is unknown and only the weights count.
+		health, _, _ := getPoolHealth(c, accountID, pool.ID)
+		if err := checkPoolCapacity(pool, origins, originHealthy(health)); err != nil {
+			return err
+		}
+	}
+
+	res, err := client.LoadBalancers.Pools.Edit(c.Context(), pool.ID, load_balancers.PoolEditParams{
+		AccountID: cloudflare.F(accountID),
+		Origins:   cloudflare.F(originParams(origins)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating pool: %w", err)
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+	}
+	o := origins[i]
+	fmt.Printf("Origin %s of pool %s: enabled %s, weight %s\n", o.Name, pool.Name, formatBool(o.Enabled), formatOriginWeight(o.Weight))
+	if prev := pool.Origins[i].Weight; o.Weight == 0 && prev > 0 {
+		fmt.Printf("Put it back with: lb pools enable-origin --pool %s --origin %s --weight %s\n", pool.Name, o.Name, formatOriginWeight(prev))
+	}
+	return nil
+}
+
+func lbPoolsEnableOrigin(c *cobra.Command, args []string) error {
+	weight, _ := c.Flags().GetFloat64("weight")
+	if c.Flags().Changed("weight") && (weight < 0 || weight > 1) {
+		return fmt.Errorf("invalid --weight %v: must be between 0 and 1", weight)
+	}
+	return updatePoolOrigin(c, true, func(o *load_balancers.Origin) error {
+		o.Enabled = true
+		if c.Flags().Changed("weight") {
+			o.Weight = weight
+		} else if o.Weight == 0 {
+			return fmt.Errorf("origin %s is drained; give --weight to put it back", o.Name)
+		}
+		return nil
+	})
+}
+
+func lbPoolsDisableOrigin(c *cobra.Command, args []string) error {
+	force, _ := c.Flags().GetBool("force")
+	return updatePoolOrigin(c, force, func(o *load_balancers.Origin) error {
+		o.Enabled = false
+		return nil
+	})
+}
+
+func lbPoolsDrain(c *cobra.Command, args []string) error {
+	force, _ := c.Flags().GetBool("force")
+	disable, _ := c.Flags().GetBool("disable")
+	return updatePoolOrigin(c, force, func(o *load_balancers.Origin) error {
+		o.Weight = 0
+		if disable {
+			o.Enabled = false
+		}
+		return nil
+	})
+}
+
+func lbPoolsHealth(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "pool"); err != nil {
+		return err
+	}
+	ref, _ := c.Flags().GetString("pool")
+	pops, _ := c.Flags().GetBool("pops")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	pool, err := findPool(c, accountID, ref)
+	if err != nil {
+		return err
+	}
+	health, raw, err := getPoolHealth(c, accountID, pool.ID)
+	if err != nil {
+		return err
+	}
+	if jsonOutput(c) {
+		return writeJSON(raw)
+	}
+
+	names := map[string]string{}
+	for _, o := range pool.Origins {
+		names[o.Address] = o.Name
+	}
+	if pops {
+		output := make([][]string, 0, len(health))
+		for _, h := range health {
+			code := ""
+			if h.ResponseCode != 0 {
+				code = strconv.FormatInt(h.ResponseCode, 10)
+			}
+			output = append(output, []string{names[h.Address], h.Address, h.POP, formatBool(h.Healthy), h.RTT, code, h.FailureReason})
+		}
+		writeTable(output, "Origin", "Address", "POP", "Healthy", "RTT", "Response Code", "Failure Reason")
+		return nil
+	}
+
+	up := map[string]int{}
+	total := map[string]int{}
+	reasons := map[string][]string{}
+	for _, h := range health {
+		total[h.Address]++
+		if h.Healthy {
+			up[h.Address]++
+		} else if h.FailureReason != "" && !slices.Contains(reasons[h.Address], h.FailureReason) {
+			reasons[h.Address] = append(reasons[h.Address], h.FailureReason)
+		}
+	}
+	healthy := originHealthy(health)
+	output := make([][]string, 0, len(pool.Origins))
+	for _, o := range pool.Origins {
+		status := "unknown"
+		if h, ok := healthy[o.Address]; ok {
+			status = formatBool(h)
+		}
+		output = append(output, []string{
+			o.Name,
+			o.Address,
+			formatBool(o.Enabled),
+			formatOriginWeight(o.Weight),
+			status,
+			fmt.Sprintf("%d/%d", up[o.Address], total[o.Address]),
+			strings.Join(reasons[o.Address], "; "),
+		})
+	}
+	writeTable(output, "Origin", "Address", "Enabled", "Weight", "Healthy", "Healthy POPs", "Failure Reasons")
+	if len(health) == 0 {
+		fmt.Fprintf(os.Stderr, "No health data for pool %s; does it have a monitor?\n", pool.Name)
+	}
+	return nil
+}
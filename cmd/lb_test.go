@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v6/load_balancers"
+)
+
+func TestParseOriginFlag(t *testing.T) {
+	o, err := parseOriginFlag("192.0.2.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Address != "192.0.2.10" || o.Name != "192.0.2.10" || o.Weight != 1 || !o.Enabled {
+		t.Errorf("parseOriginFlag(address) = %+v", o)
+	}
+
+	o, err = parseOriginFlag("app.example.com, name=web1, weight=0.5, port=8443, host=app.example.com, enabled=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Address != "app.example.com" || o.Name != "web1" || o.Weight != 0.5 || o.Port != 8443 || o.Enabled ||
+		!reflect.DeepEqual(o.Header.Host, []string{"app.example.com"}) {
+		t.Errorf("parseOriginFlag(settings) = %+v", o)
+	}
+
+	for _, s := range []string{"", "name=web1", "192.0.2.10,web1", "192.0.2.10,weight=2", "192.0.2.10,port=x", "192.0.2.10,colour=red"} {
+		if _, err := parseOriginFlag(s); err == nil {
+			t.Errorf("parseOriginFlag(%q) = nil error; want error", s)
+		}
+	}
+
+	if _, err := parseOriginFlags([]string{"192.0.2.10", "192.0.2.10"}); err == nil {
+		t.Error("parseOriginFlags(duplicate) = nil error; want error")
+	}
+}
+
+func TestParsePoolHealth(t *testing.T) {
+	raw := []byte(`{"pool_id":"p1","pop_health":{
+		"Amsterdam, NL":{"healthy":true,"origins":[{"192.0.2.10":{"healthy":true,"rtt":"12.1ms","response_code":200,"failure_reason":"No failures"}},{"192.0.2.11":{"healthy":false,"rtt":"0ms","failure_reason":"TCP connection failed"}}]},
+		"Singapore, SG":{"healthy":true,"origins":[{"192.0.2.10":{"healthy":true,"rtt":"80ms","response_code":200}},{"192.0.2.11":{"healthy":true,"rtt":"81ms","response_code":200}}]}}}`)
+	health, err := parsePoolHealth(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(health) != 4 {
+		t.Fatalf("parsePoolHealth() returned %d entries; want 4", len(health))
+	}
+	if h := health[2]; h.Address != "192.0.2.11" || h.POP != "Amsterdam, NL" || h.Healthy || h.FailureReason != "TCP connection failed" {
+		t.Errorf("parsePoolHealth()[2] = %+v", h)
+	}
+	want := map[string]bool{"192.0.2.10": true, "192.0.2.11": false}
+	if got := originHealthy(health); !reflect.DeepEqual(got, want) {
+		t.Errorf("originHealthy() = %v; want %v", got, want)
+	}
+
+	single := []byte(`{"pool_id":"p1","pop_health":{"healthy":true,"origins":[{"192.0.2.10":{"healthy":true,"rtt":"5ms"}}]}}`)
+	health, err = parsePoolHealth(single)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(health) != 1 || health[0].Address != "192.0.2.10" || !health[0].Healthy {
+		t.Errorf("parsePoolHealth(single) = %+v", health)
+	}
+}
+
+func TestCheckPoolCapacity(t *testing.T) {
+	pool := load_balancers.Pool{Name: "web", MinimumOrigins: 2}
+	origins := []load_balancers.Origin{
+		{Name: "a", Address: "192.0.2.1", Enabled: true, Weight: 1},
+		{Name: "b", Address: "192.0.2.2", Enabled: true, Weight: 1},
+		{Name: "c", Address: "192.0.2.3", Enabled: true, Weight: 0},
+	}
+	if err := checkPoolCapacity(pool, origins, nil); err != nil {
+		t.Errorf("checkPoolCapacity(two serving) = %v", err)
+	}
+	if err := checkPoolCapacity(pool, origins, map[string]bool{"192.0.2.2": false}); err == nil {
+		t.Error("checkPoolCapacity(one healthy) = nil error; want error")
+	}
+	origins[0].Enabled = false
+	if err := checkPoolCapacity(pool, origins, nil); err == nil {
+		t.Error("checkPoolCapacity(one enabled) = nil error; want error")
+	}
+
+	pool.MinimumOrigins = 0
+	if err := checkPoolCapacity(pool, origins[2:], nil); err == nil {
+		t.Error("checkPoolCapacity(all drained) = nil error; want error")
+	}
+}
+
+func TestLBMonitorSpecValidate(t *testing.T) {
+	valid := lbMonitorSpec{Type: "https", Method: "GET", Path: "/health", ExpectedCodes: "2xx", Interval: 60, Timeout: 5, Retries: 2}
+	if err := valid.validate(); err != nil {
+		t.Errorf("validate(valid) = %v", err)
+	}
+	tcp := lbMonitorSpec{Type: "tcp", Port: 5432, Interval: 60, Timeout: 5}
+	if err := tcp.validate(); err != nil {
+		t.Errorf("validate(tcp) = %v", err)
+	}
+	if params := tcp.newParams("acc"); params.Path.Present || params.ExpectedCodes.Present {
+		t.Error("newParams(tcp) sends HTTP settings")
+	}
+
+	for name, change := range map[string]func(s *lbMonitorSpec){
+		"type":      func(s *lbMonitorSpec) { s.Type = "ftp" },
+		"codes":     func(s *lbMonitorSpec) { s.ExpectedCodes = "" },
+		"interval":  func(s *lbMonitorSpec) { s.Interval = 1 },
+		"timeout":   func(s *lbMonitorSpec) { s.Timeout = 60 },
+		"retries":   func(s *lbMonitorSpec) { s.Retries = 9 },
+		"icmp port": func(s *lbMonitorSpec) { s.Type = "icmp_ping"; s.Port = 80 },
+	} {
+		s := valid
+		change(&s)
+		if err := s.validate(); err == nil {
+			t.Errorf("validate(%s) = nil error; want error", name)
+		}
+	}
+
+	if _, err := parseHeaderFlags([]string{"Host: example.com", "X-Check:  1"}); err != nil {
+		t.Errorf("parseHeaderFlags() = %v", err)
+	}
+	if _, err := parseHeaderFlags([]string{"no colon"}); err == nil {
+		t.Error("parseHeaderFlags(no colon) = nil error; want error")
+	}
+}
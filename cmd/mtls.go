@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/mtls_certificates"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var mtlsCmd = &cobra.Command{
+	Use:   "mtls-certificates",
+	Short: "Account mTLS certificates",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var mtlsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the mTLS certificates of an account",
+	RunE:    mtlsList,
+}
+
+var mtlsUploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload a CA or leaf mTLS certificate",
+	RunE:  mtlsUpload,
+}
+
+var mtlsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show an mTLS certificate",
+	RunE:  mtlsGet,
+}
+
+var mtlsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an mTLS certificate",
+	RunE:  mtlsDelete,
+}
+
+var mtlsAssociationsCmd = &cobra.Command{
+	Use:   "associations",
+	Short: "List the services using an mTLS certificate",
+	RunE:  mtlsAssociations,
+}
+
+func init() {
+	rootCmd.AddCommand(mtlsCmd)
+	mtlsCmd.AddCommand(mtlsListCmd)
+	mtlsCmd.AddCommand(mtlsUploadCmd)
+	mtlsCmd.AddCommand(mtlsGetCmd)
+	mtlsCmd.AddCommand(mtlsDeleteCmd)
+	mtlsCmd.AddCommand(mtlsAssociationsCmd)
+
+	mtlsCmd.PersistentFlags().String("account", "", "account name or ID")
+
+	mtlsUploadCmd.Flags().String("cert", "", "PEM file with the certificate chain")
+	mtlsUploadCmd.Flags().String("key", "", "PEM file with the private key, only needed by some services")
+	mtlsUploadCmd.Flags().Bool("ca", false, "the certificate is a CA certificate")
+	mtlsUploadCmd.Flags().String("name", "", "name of the certificate")
+
+	for _, c := range []*cobra.Command{mtlsGetCmd, mtlsDeleteCmd, mtlsAssociationsCmd} {
+		c.Flags().String("id", "", "mTLS certificate ID")
+	}
+}
+
+// checkMTLSCertificates checks a PEM chain before upload: a CA upload must
+// start with a CA certificate and a leaf upload must not, and none of the
+// certificates may have expired.
+func checkMTLSCertificates(data []byte, ca bool) error {
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return err
+	}
+	first := certs[0]
+	isCA := first.BasicConstraintsValid && first.IsCA
+	if ca && !isCA {
+		return fmt.Errorf("%s is not a CA certificate; leave out --ca to upload a leaf certificate", first.Subject.CommonName)
+	}
+	if !ca && isCA {
+		return fmt.Errorf("%s is a CA certificate; use --ca to upload it", first.Subject.CommonName)
+	}
+	for _, cert := range certs {
+		if time.Now().After(cert.NotAfter) {
+			return fmt.Errorf("%s expired on %s", cert.Subject.CommonName, cert.NotAfter.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+func formatMTLSCertificate(cert mtls_certificates.MTLSCertificate) []string {
+	return []string{
+		cert.ID,
+		cert.Name,
+		formatBool(cert.CA),
+		cert.Issuer,
+		cert.ExpiresOn.Format(time.RFC3339),
+	}
+}
+
+func writeMTLSCertificates(c *cobra.Command, certs []mtls_certificates.MTLSCertificate) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(certs))
+		for _, cert := range certs {
+			raw = append(raw, json.RawMessage(cert.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(certs))
+	for _, cert := range certs {
+		output = append(output, formatMTLSCertificate(cert))
+	}
+	writeTable(output, "ID", "Name", "CA", "Issuer", "Expires On")
+	return nil
+}
+
+func mtlsList(c *cobra.Command, args []string) error {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	var certs []mtls_certificates.MTLSCertificate
+	iter := client.MTLSCertificates.ListAutoPaging(c.Context(), mtls_certificates.MTLSCertificateListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		certs = append(certs, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing mTLS certificates: %w", err)
+	}
+	return writeMTLSCertificates(c, certs)
+}
+
+func mtlsUpload(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "cert"); err != nil {
+		return err
+	}
+	certFile, _ := c.Flags().GetString("cert")
+	keyFile, _ := c.Flags().GetString("key")
+	ca, _ := c.Flags().GetBool("ca")
+	name, _ := c.Flags().GetString("name")
+
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return err
+	}
+	if err := checkMTLSCertificates(certPEM, ca); err != nil {
+		return fmt.Errorf("%s: %w", certFile, err)
+	}
+	params := mtls_certificates.MTLSCertificateNewParams{
+		CA:           cloudflare.F(ca),
+		Certificates: cloudflare.F(string(certPEM)),
+	}
+	if keyFile != "" {
+		if ca {
+			return errors.New("--key cannot be used with --ca")
+		}
+		_, keyPEM, _, err := loadCertificateKeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		params.PrivateKey = cloudflare.F(keyPEM)
+	}
+	if name != "" {
+		params.Name = cloudflare.F(name)
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+	params.AccountID = cloudflare.F(accountID)
+
+	res, err := client.MTLSCertificates.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error uploading mTLS certificate: %w", err)
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+	}
+	writeTable([][]string{{
+		res.ID,
+		res.Name,
+		formatBool(res.CA),
+		res.Issuer,
+		res.ExpiresOn.Format(time.RFC3339),
+	}}, "ID", "Name", "CA", "Issuer", "Expires On")
+	return nil
+}
+
+func mtlsGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	cert, err := client.MTLSCertificates.Get(c.Context(), id, mtls_certificates.MTLSCertificateGetParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting mTLS certificate: %w", err)
+	}
+	return writeMTLSCertificates(c, []mtls_certificates.MTLSCertificate{*cert})
+}
+
+func mtlsDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	cert, err := client.MTLSCertificates.Delete(c.Context(), id, mtls_certificates.MTLSCertificateDeleteParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting mTLS certificate: %w", err)
+	}
+	fmt.Printf("Deleted mTLS certificate %s\n", cert.ID)
+	return nil
+}
+
+func mtlsAssociations(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	var associations []mtls_certificates.CertificateAsssociation
+	iter := client.MTLSCertificates.Associations.GetAutoPaging(c.Context(), id, mtls_certificates.AssociationGetParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		associations = append(associations, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing mTLS certificate associations: %w", err)
+	}
+
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(associations))
+		for _, a := range associations {
+			raw = append(raw, json.RawMessage(a.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+	output := make([][]string, 0, len(associations))
+	for _, a := range associations {
+		output = append(output, []string{a.Service, a.Status})
+	}
+	writeTable(output, "Service", "Status")
+	return nil
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestCheckMTLSCertificates(t *testing.T) {
+	root, rootKey := newTestCert(t, "Test Root", nil, nil)
+	leaf, _ := newTestCert(t, "client.example.com", root, rootKey)
+	rootPEM := encodeCertificates([]*x509.Certificate{root})
+	leafPEM := encodeCertificates([]*x509.Certificate{leaf, root})
+
+	if err := checkMTLSCertificates(rootPEM, true); err != nil {
+		t.Errorf("checkMTLSCertificates(root, ca) = %v", err)
+	}
+	if err := checkMTLSCertificates(leafPEM, false); err != nil {
+		t.Errorf("checkMTLSCertificates(leaf, leaf) = %v", err)
+	}
+	if err := checkMTLSCertificates(rootPEM, false); err == nil {
+		t.Error("checkMTLSCertificates(root, leaf) = nil error; want error")
+	}
+	if err := checkMTLSCertificates(leafPEM, true); err == nil {
+		t.Error("checkMTLSCertificates(leaf, ca) = nil error; want error")
+	}
+	if err := checkMTLSCertificates([]byte("not a certificate"), true); err == nil {
+		t.Error("checkMTLSCertificates(garbage) = nil error; want error")
+	}
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/origin_ca_certificates"
+	"github.com/cloudflare/cloudflare-go/v6/shared"
+	"github.com/cloudflare/cloudflare-go/v6/ssl"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var originCACmd = &cobra.Command{
+	Use:   "origin-ca",
+	Short: "Issue, list and revoke Origin CA certificates",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var originCACreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Generate a private key and CSR locally and issue an Origin CA certificate for it",
+	RunE:  originCACreate,
+}
+
+var originCAListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the Origin CA certificates of a zone",
+	RunE:    originCAList,
+}
+
+var originCAGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show an Origin CA certificate",
+	RunE:  originCAGet,
+}
+
+var originCARevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke an Origin CA certificate",
+	RunE:  originCARevoke,
+}
+
+func init() {
+	rootCmd.AddCommand(originCACmd)
+	originCACmd.AddCommand(originCACreateCmd)
+	originCACmd.AddCommand(originCAListCmd)
+	originCACmd.AddCommand(originCAGetCmd)
+	originCACmd.AddCommand(originCARevokeCmd)
+
+	originCACreateCmd.Flags().StringArray("hostname", nil, "hostname or wildcard (*.example.com) to cover (repeatable)")
+	originCACreateCmd.Flags().Int("validity", 5475, "validity in days: 7, 30, 90, 365, 730, 1095 or 5475")
+	originCACreateCmd.Flags().String("type", "rsa", "key type: rsa or ecc")
+	originCACreateCmd.Flags().String("key-out", "", "private key file (default <hostname>.key)")
+	originCACreateCmd.Flags().String("cert-out", "", "certificate file (default <hostname>.pem)")
+	originCACreateCmd.Flags().Bool("force", false, "overwrite existing key and certificate files")
+
+	originCAListCmd.Flags().String("zone", "", "zone name")
+
+	originCAGetCmd.Flags().String("id", "", "certificate ID")
+	originCAGetCmd.Flags().String("out", "", "also write the certificate to this file")
+
+	originCARevokeCmd.Flags().String("id", "", "certificate ID")
+}
+
+// checkOriginCAHostnames checks that every hostname is a plain name or a
+// wildcard in its leftmost label only.
+func checkOriginCAHostnames(hostnames []string) error {
+	if len(hostnames) == 0 {
+		return errors.New("at least one --hostname is required")
+	}
+	for _, h := range hostnames {
+		name := strings.TrimPrefix(h, "*.")
+		if name == "" || strings.Contains(name, "*") || strings.ContainsAny(name, " /:") || !strings.Contains(name, ".") {
+			return fmt.Errorf("invalid hostname %q", h)
+		}
+	}
+	return nil
+}
+
+// newOriginCAKey generates a private key of the kind used by the Origin CA
+// request type: RSA 2048 or ECDSA P-256.
+func newOriginCAKey(keyType string) (crypto.Signer, shared.CertificateRequestType, error) {
+	switch keyType {
+	case "rsa":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		return key, shared.CertificateRequestTypeOriginRSA, err
+	case "ecc":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		return key, shared.CertificateRequestTypeOriginECC, err
+	}
+	return nil, "", fmt.Errorf("invalid key type %q: must be rsa or ecc", keyType)
+}
+
+// originCACSR creates a PEM encoded certificate signing request for the
+// hostnames, with the first one as common name.
+func originCACSR(key crypto.Signer, hostnames []string) (string, error) {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: hostnames[0]},
+		DNSNames: hostnames,
+	}, key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// encodePrivateKey returns the PKCS #8 PEM encoding of key.
+func encodePrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// originCAFilename returns the default file name for a hostname, with the
+// wildcard label spelled out.
+func originCAFilename(hostname, ext string) string {
+	return strings.Replace(hostname, "*", "_wildcard", 1) + ext
+}
+
+// checkCreateFile fails if path exists and force is not set, so that nothing
+// is requested from the API when the result could not be written.
+func checkCreateFile(path string, force bool) error {
+	if force {
+		return nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+	return nil
+}
+
+// writeFileMode writes data to path with perm. An existing file gets perm
+// before anything is written to it, so a key never lands in a file that
+// others can read.
+func writeFileMode(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func formatOriginCACertificate(cert origin_ca_certificates.OriginCACertificate) []string {
+	return []string{
+		cert.ID,
+		strings.Join(cert.Hostnames, ", "),
+		string(cert.RequestType),
+		strconv.Itoa(int(cert.RequestedValidity)),
+		cert.ExpiresOn,
+	}
+}
+
+func writeOriginCACertificates(c *cobra.Command, certs []origin_ca_certificates.OriginCACertificate) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(certs))
+		for _, cert := range certs {
+			raw = append(raw, json.RawMessage(cert.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(certs))
+	for _, cert := range certs {
+		output = append(output, formatOriginCACertificate(cert))
+	}
+	writeTable(output, "ID", "Hostnames", "Type", "Validity", "Expires On")
+	return nil
+}
+
+func originCACreate(c *cobra.Command, args []string) error {
+	hostnames, _ := c.Flags().GetStringArray("hostname")
+	validity, _ := c.Flags().GetInt("validity")
+	keyType, _ := c.Flags().GetString("type")
+	keyOut, _ := c.Flags().GetString("key-out")
+	certOut, _ := c.Flags().GetString("cert-out")
+	force, _ := c.Flags().GetBool("force")
+
+	if err := checkOriginCAHostnames(hostnames); err != nil {
+		return err
+	}
+	if !ssl.RequestValidity(validity).IsKnown() {
+		return fmt.Errorf("invalid validity %d: must be 7, 30, 90, 365, 730, 1095 or 5475", validity)
+	}
+	if keyOut == "" {
+		keyOut = originCAFilename(hostnames[0], ".key")
+	}
+	if certOut == "" {
+		certOut = originCAFilename(hostnames[0], ".pem")
+	}
+	if keyOut == certOut {
+		return errors.New("--key-out and --cert-out must be different files")
+	}
+	for _, path := range []string{keyOut, certOut} {
+		if err := checkCreateFile(path, force); err != nil {
+			return err
+		}
+	}
+
+	key, requestType, err := newOriginCAKey(keyType)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		return err
+	}
+	csr, err := originCACSR(key, hostnames)
+	if err != nil {
+		return fmt.Errorf("Error creating CSR: %w", err)
+	}
+
+	cert, err := client.OriginCACertificates.New(c.Context(), origin_ca_certificates.OriginCACertificateNewParams{
+		Csr:               cloudflare.F(csr),
+		Hostnames:         cloudflare.F(hostnames),
+		RequestType:       cloudflare.F(requestType),
+		RequestedValidity: cloudflare.F(ssl.RequestValidity(validity)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating Origin CA certificate: %w", err)
+	}
+
+	// The key is only ever written locally, readable by its owner alone.
+	if err := writeFileMode(keyOut, keyPEM, 0o600); err != nil {
+		return fmt.Errorf("Error writing private key (certificate %s was issued): %w", cert.ID, err)
+	}
+	if err := writeFileMode(certOut, []byte(strings.TrimSpace(cert.Certificate)+"\n"), 0o644); err != nil {
+		return fmt.Errorf("Error writing certificate %s: %w", cert.ID, err)
+	}
+
+	if err := writeOriginCACertificates(c, []origin_ca_certificates.OriginCACertificate{*cert}); err != nil {
+		return err
+	}
+	if !jsonOutput(c) {
+		fmt.Printf("Wrote private key to %s and certificate to %s\n", keyOut, certOut)
+	}
+	return nil
+}
+
+func originCAList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var certs []origin_ca_certificates.OriginCACertificate
+	iter := client.OriginCACertificates.ListAutoPaging(c.Context(), origin_ca_certificates.OriginCACertificateListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		certs = append(certs, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing Origin CA certificates: %w", err)
+	}
+	return writeOriginCACertificates(c, certs)
+}
+
+func originCAGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+	out, _ := c.Flags().GetString("out")
+
+	cert, err := client.OriginCACertificates.Get(c.Context(), id)
+	if err != nil {
+		return fmt.Errorf("Error getting Origin CA certificate: %w", err)
+	}
+	if out != "" {
+		if err := writeFileMode(out, []byte(strings.TrimSpace(cert.Certificate)+"\n"), 0o644); err != nil {
+			return err
+		}
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(cert.JSON.RawJSON()))
+	}
+	if err := writeOriginCACertificates(c, []origin_ca_certificates.OriginCACertificate{*cert}); err != nil {
+		return err
+	}
+	if out == "" {
+		fmt.Println(strings.TrimSpace(cert.Certificate))
+	}
+	return nil
+}
+
+func originCARevoke(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "id"); err != nil {
+		return err
+	}
+	id, _ := c.Flags().GetString("id")
+
+	res, err := client.OriginCACertificates.Delete(c.Context(), id)
+	if err != nil {
+		return fmt.Errorf("Error revoking Origin CA certificate: %w", err)
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+	}
+	fmt.Printf("Revoked certificate %s at %s\n", res.ID, res.RevokedAt.Format("2006-01-02 15:04:05 MST"))
+	return nil
+}
@@ -1,8 +1,20 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"embed"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -11,25 +23,145 @@ var originCARootCertCmd = &cobra.Command{
 	Use:     "origin-ca-root-cert",
 	Aliases: []string{"ocrc"},
 	Short:   "Print Origin CA Root Certificate (in PEM format)",
+	Long: `Print the Origin CA root certificate in PEM format, with its subject, expiry
+and fingerprint on stderr.
+
+The certificate is fetched from the API. If the API cannot be reached, or with
+--offline, the copy built into flarectl is used.
+With --bundle, the certificates of a local PEM file are checked against the
+root and written in front of it as a full chain.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return originCARootCertificate(cmd)
 	},
 }
 
+// originCARoots holds the root certificates published by Cloudflare, used when
+// the API cannot be reached. Refresh them with make fetch-origin-ca-roots.
+//
+//go:embed origin_ca_roots
+var originCARoots embed.FS
+
 func init() {
 	rootCmd.AddCommand(originCARootCertCmd)
 	originCARootCertCmd.Flags().String("algorithm", "", "certificate algorithm ( ecc | rsa )")
 	originCARootCertCmd.MarkFlagRequired("algorithm")
+	originCARootCertCmd.Flags().String("out", "", "write the certificate to this file instead of stdout")
+	originCARootCertCmd.Flags().String("bundle", "", "PEM file with a leaf certificate to chain to the root")
+	originCARootCertCmd.Flags().Bool("offline", false, "use the built-in copy of the root certificate")
 }
 
-func originCARootCertificate(c *cobra.Command) error {
-	if err := ensureClient(); err != nil {
-		return err
+// originCARootInfo describes a root certificate for the --json output.
+type originCARootInfo struct {
+	Algorithm   string    `json:"algorithm"`
+	Source      string    `json:"source"`
+	Subject     string    `json:"subject"`
+	NotAfter    time.Time `json:"not_after"`
+	Fingerprint string    `json:"fingerprint_sha256"`
+	PEM         string    `json:"pem"`
+}
+
+func checkOriginCAAlgorithm(alg string) error {
+	if alg != "ecc" && alg != "rsa" {
+		return fmt.Errorf("invalid algorithm %q: must be ecc or rsa", alg)
 	}
+	return nil
+}
 
-	alg, _ := c.Flags().GetString("algorithm")
+// parseCertificates parses all CERTIFICATE blocks of a PEM file. It fails if
+// there are none or if anything else than certificates is found.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		return nil, errors.New("trailing data after the last PEM block")
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return certs, nil
+}
+
+// certFingerprint returns the SHA-256 fingerprint of cert as colon separated
+// hex, the way browsers and openssl show it.
+func certFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	h := strings.ToUpper(hex.EncodeToString(sum[:]))
+	parts := make([]string, 0, len(sum))
+	for i := 0; i < len(h); i += 2 {
+		parts = append(parts, h[i:i+2])
+	}
+	return strings.Join(parts, ":")
+}
+
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.Bytes()
+}
+
+// bundleOriginCARoot checks that the first certificate of leafPEM chains up to
+// root, through any intermediates that follow it, and returns the full chain
+// ending with root.
+func bundleOriginCARoot(leafPEM []byte, root *x509.Certificate) ([]byte, error) {
+	certs, err := parseCertificates(leafPEM)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("certificate for %s does not chain to the root: %w", certs[0].Subject.CommonName, err)
+	}
+	return encodeCertificates(append(certs, root)), nil
+}
+
+// embeddedOriginCARoot returns the built-in copy of the root certificate.
+func embeddedOriginCARoot(alg string) ([]byte, error) {
+	data, err := originCARoots.ReadFile("origin_ca_roots/origin_ca_" + alg + "_root.pem")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no built-in %s root certificate; run make fetch-origin-ca-roots and rebuild", alg)
+	}
+	return data, err
+}
 
-	path := fmt.Sprintf("cert_req?certificate_chain_type=%s", alg)
+// isNetworkError reports whether err is a failure to reach the API, as opposed
+// to an error returned by it.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// fetchOriginCARoot gets the root certificate from the API.
+func fetchOriginCARoot(c *cobra.Command, alg string) ([]byte, error) {
+	if err := ensureClient(); err != nil {
+		return nil, err
+	}
+
+	path := "cert_req?" + url.Values{"certificate_chain_type": {alg}}.Encode()
 
 	var res struct {
 		Result struct {
@@ -43,17 +175,90 @@ func originCARootCertificate(c *cobra.Command) error {
 
 	err := client.Get(c.Context(), path, nil, &res)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !res.Success {
 		if len(res.Errors) > 0 {
-			return fmt.Errorf("api error: %s", res.Errors[0].Message)
+			return nil, fmt.Errorf("api error: %s", res.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("api error: unknown error")
+	}
+	return []byte(res.Result.Certificate), nil
+}
+
+func originCARootCertificate(c *cobra.Command) error {
+	alg, _ := c.Flags().GetString("algorithm")
+	out, _ := c.Flags().GetString("out")
+	bundle, _ := c.Flags().GetString("bundle")
+	offline, _ := c.Flags().GetBool("offline")
+
+	if err := checkOriginCAAlgorithm(alg); err != nil {
+		return err
+	}
+
+	source := "api"
+	var data []byte
+	var err error
+	if !offline {
+		data, err = fetchOriginCARoot(c, alg)
+		if err != nil && !isNetworkError(err) {
+			return err
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not fetch the root certificate (%v), using the built-in copy\n", err)
+		}
+	}
+	if offline || err != nil {
+		source = "built-in"
+		if data, err = embeddedOriginCARoot(alg); err != nil {
+			return err
+		}
+	}
+
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return fmt.Errorf("invalid root certificate: %w", err)
+	}
+	root := certs[0]
+	output := encodeCertificates(certs[:1])
+
+	if bundle != "" {
+		leaf, err := os.ReadFile(bundle)
+		if err != nil {
+			return err
+		}
+		if output, err = bundleOriginCARoot(leaf, root); err != nil {
+			return fmt.Errorf("Error bundling %s: %w", bundle, err)
 		}
-		return fmt.Errorf("api error: unknown error")
 	}
 
-	fmt.Println(strings.TrimSpace(res.Result.Certificate))
+	info := originCARootInfo{
+		Algorithm:   alg,
+		Source:      source,
+		Subject:     root.Subject.String(),
+		NotAfter:    root.NotAfter,
+		Fingerprint: certFingerprint(root),
+		PEM:         string(output),
+	}
 
+	if out != "" {
+		if err := writeFileMode(out, output, 0o644); err != nil {
+			return err
+		}
+	}
+	if jsonOutput(c) {
+		return writeJSON(info)
+	}
+	if out == "" {
+		fmt.Print(string(output))
+	}
+	fmt.Fprintf(os.Stderr, "Subject:     %s\n", info.Subject)
+	fmt.Fprintf(os.Stderr, "Expires:     %s\n", info.NotAfter.Format("2006-01-02"))
+	fmt.Fprintf(os.Stderr, "SHA-256:     %s\n", info.Fingerprint)
+	fmt.Fprintf(os.Stderr, "Source:      %s\n", info.Source)
+	if out != "" {
+		fmt.Fprintf(os.Stderr, "Written to:  %s\n", out)
+	}
 	return nil
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v6/shared"
+)
+
+func TestCheckOriginCAHostnames(t *testing.T) {
+	if err := checkOriginCAHostnames([]string{"example.com", "*.example.com"}); err != nil {
+		t.Errorf("checkOriginCAHostnames() = %v; want nil", err)
+	}
+	for _, bad := range [][]string{nil, {"*"}, {"www.*.example.com"}, {"https://example.com"}, {"localhost"}} {
+		if err := checkOriginCAHostnames(bad); err == nil {
+			t.Errorf("checkOriginCAHostnames(%q) = nil; want error", bad)
+		}
+	}
+}
+
+func TestOriginCACSR(t *testing.T) {
+	hostnames := []string{"example.com", "*.example.com"}
+	for _, tt := range []struct {
+		keyType     string
+		requestType shared.CertificateRequestType
+		algorithm   x509.PublicKeyAlgorithm
+	}{
+		{"rsa", shared.CertificateRequestTypeOriginRSA, x509.RSA},
+		{"ecc", shared.CertificateRequestTypeOriginECC, x509.ECDSA},
+	} {
+		key, requestType, err := newOriginCAKey(tt.keyType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if requestType != tt.requestType {
+			t.Errorf("newOriginCAKey(%q) request type = %s; want %s", tt.keyType, requestType, tt.requestType)
+		}
+
+		csrPEM, err := originCACSR(key, hostnames)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode([]byte(csrPEM))
+		if block == nil || block.Type != "CERTIFICATE REQUEST" {
+			t.Fatalf("originCACSR() = %q; want a PEM certificate request", csrPEM)
+		}
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := csr.CheckSignature(); err != nil {
+			t.Errorf("CSR signature: %v", err)
+		}
+		if csr.PublicKeyAlgorithm != tt.algorithm || csr.Subject.CommonName != "example.com" || !reflect.DeepEqual(csr.DNSNames, hostnames) {
+			t.Errorf("CSR = %v %q %q", csr.PublicKeyAlgorithm, csr.Subject.CommonName, csr.DNSNames)
+		}
+
+		keyPEM, err := encodePrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ = pem.Decode(keyPEM)
+		if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+			t.Errorf("encodePrivateKey(): %v", err)
+		}
+	}
+
+	if _, _, err := newOriginCAKey("dsa"); err == nil {
+		t.Error("newOriginCAKey(dsa) = nil error; want error")
+	}
+}
+
+func TestWriteFileMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "example.com.key")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCreateFile(path, false); err == nil {
+		t.Error("checkCreateFile() on existing file = nil; want error")
+	}
+
+	if err := writeFileMode(path, []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v; want 0600", fi.Mode().Perm())
+	}
+	if b, _ := os.ReadFile(path); string(b) != "key" {
+		t.Errorf("content = %q; want key", b)
+	}
+
+	if got := originCAFilename("*.example.com", ".pem"); got != "_wildcard.example.com.pem" {
+		t.Errorf("originCAFilename() = %q", got)
+	}
+}
@@ -0,0 +1,549 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/origin_tls_client_auth"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var originPullsCmd = &cobra.Command{
+	Use:     "origin-pulls",
+	Aliases: []string{"aop"},
+	Short:   "Authenticated origin pulls: client certificates presented to the origin",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var originPullsSettingCmd = &cobra.Command{
+	Use:   "setting",
+	Short: "Show or change zone-level authenticated origin pulls",
+	RunE:  originPullsSetting,
+}
+
+var originPullsCertsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Zone-level client certificates",
+}
+
+var originPullsCertsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the zone-level client certificates",
+	RunE:    originPullsCertsList,
+}
+
+var originPullsCertsUploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload a zone-level client certificate and its private key",
+	RunE:  originPullsCertsUpload,
+}
+
+var originPullsCertsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a zone-level client certificate",
+	RunE:  originPullsCertsDelete,
+}
+
+var originPullsHostnamesCmd = &cobra.Command{
+	Use:   "hostnames",
+	Short: "Per-hostname client certificates and settings",
+}
+
+var originPullsHostnamesGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the per-hostname setting of a hostname",
+	RunE:  originPullsHostnamesGet,
+}
+
+var originPullsHostnamesSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Associate hostnames with a certificate and turn their origin pulls on or off",
+	RunE:  originPullsHostnamesSet,
+}
+
+var originPullsHostnamesRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove the certificate association of hostnames",
+	RunE:  originPullsHostnamesRemove,
+}
+
+var originPullsHostnamesCertsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Per-hostname client certificates",
+}
+
+var originPullsHostnamesCertsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the per-hostname client certificates",
+	RunE:    originPullsHostnamesCertsList,
+}
+
+var originPullsHostnamesCertsUploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload a per-hostname client certificate and its private key",
+	RunE:  originPullsHostnamesCertsUpload,
+}
+
+var originPullsHostnamesCertsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a per-hostname client certificate",
+	RunE:  originPullsHostnamesCertsDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(originPullsCmd)
+	originPullsCmd.AddCommand(originPullsSettingCmd)
+	originPullsCmd.AddCommand(originPullsCertsCmd)
+	originPullsCertsCmd.AddCommand(originPullsCertsListCmd)
+	originPullsCertsCmd.AddCommand(originPullsCertsUploadCmd)
+	originPullsCertsCmd.AddCommand(originPullsCertsDeleteCmd)
+	originPullsCmd.AddCommand(originPullsHostnamesCmd)
+	originPullsHostnamesCmd.AddCommand(originPullsHostnamesGetCmd)
+	originPullsHostnamesCmd.AddCommand(originPullsHostnamesSetCmd)
+	originPullsHostnamesCmd.AddCommand(originPullsHostnamesRemoveCmd)
+	originPullsHostnamesCmd.AddCommand(originPullsHostnamesCertsCmd)
+	originPullsHostnamesCertsCmd.AddCommand(originPullsHostnamesCertsListCmd)
+	originPullsHostnamesCertsCmd.AddCommand(originPullsHostnamesCertsUploadCmd)
+	originPullsHostnamesCertsCmd.AddCommand(originPullsHostnamesCertsDeleteCmd)
+
+	originPullsSettingCmd.Flags().String("zone", "", "zone name")
+	originPullsSettingCmd.Flags().String("value", "", "on or off")
+
+	for _, c := range []*cobra.Command{originPullsCertsListCmd, originPullsHostnamesCertsListCmd} {
+		c.Flags().String("zone", "", "zone name")
+	}
+	for _, c := range []*cobra.Command{originPullsCertsUploadCmd, originPullsHostnamesCertsUploadCmd} {
+		c.Flags().String("zone", "", "zone name")
+		c.Flags().String("cert", "", "PEM file with the client certificate")
+		c.Flags().String("key", "", "PEM file with the private key")
+	}
+	for _, c := range []*cobra.Command{originPullsCertsDeleteCmd, originPullsHostnamesCertsDeleteCmd} {
+		c.Flags().String("zone", "", "zone name")
+		c.Flags().String("id", "", "certificate ID")
+	}
+
+	originPullsHostnamesGetCmd.Flags().String("zone", "", "zone name")
+	originPullsHostnamesGetCmd.Flags().String("hostname", "", "hostname")
+
+	originPullsHostnamesSetCmd.Flags().String("zone", "", "zone name")
+	originPullsHostnamesSetCmd.Flags().StringArray("hostname", nil, "hostname (repeatable)")
+	originPullsHostnamesSetCmd.Flags().String("cert-id", "", "per-hostname certificate ID")
+	originPullsHostnamesSetCmd.Flags().String("value", "on", "on or off")
+
+	originPullsHostnamesRemoveCmd.Flags().String("zone", "", "zone name")
+	originPullsHostnamesRemoveCmd.Flags().StringArray("hostname", nil, "hostname (repeatable)")
+}
+
+// originPullCertificate holds the fields of the different authenticated
+// origin pulls responses that flarectl uses, decoded from their raw JSON.
+type originPullCertificate struct {
+	ID         string    `json:"id"`
+	CertID     string    `json:"cert_id"`
+	Hostname   string    `json:"hostname"`
+	Enabled    *bool     `json:"enabled"`
+	Status     string    `json:"status"`
+	CertStatus string    `json:"cert_status"`
+	Issuer     string    `json:"issuer"`
+	ExpiresOn  time.Time `json:"expires_on"`
+
+	raw json.RawMessage
+}
+
+func originPullCertificateFromRaw(raw string) (originPullCertificate, error) {
+	var p originPullCertificate
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		return p, err
+	}
+	p.raw = json.RawMessage(raw)
+	return p, nil
+}
+
+// checkClientCertificate checks that a certificate may be used by Cloudflare
+// to authenticate itself to the origin.
+func checkClientCertificate(cert *x509.Certificate) error {
+	if len(cert.ExtKeyUsage) == 0 ||
+		slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageClientAuth) ||
+		slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		return nil
+	}
+	return errors.New("the certificate is not valid for client authentication (extended key usage lacks clientAuth)")
+}
+
+// loadClientCertificate reads and checks a client certificate and its key.
+func loadClientCertificate(c *cobra.Command) (string, string, error) {
+	certFile, _ := c.Flags().GetString("cert")
+	keyFile, _ := c.Flags().GetString("key")
+	certPEM, keyPEM, leaf, err := loadCertificateKeyPair(certFile, keyFile)
+	if err != nil {
+		return "", "", err
+	}
+	if err := checkClientCertificate(leaf); err != nil {
+		return "", "", fmt.Errorf("%s: %w", certFile, err)
+	}
+	warnCertificateExpiry(certFile, leaf)
+	return certPEM, keyPEM, nil
+}
+
+func formatOriginPullEnabled(enabled *bool) string {
+	if enabled == nil {
+		return ""
+	}
+	return formatBool(*enabled)
+}
+
+func formatOriginPullExpiry(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func writeOriginPullCertificates(c *cobra.Command, certs []originPullCertificate) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(certs))
+		for _, p := range certs {
+			raw = append(raw, p.raw)
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(certs))
+	for _, p := range certs {
+		output = append(output, []string{p.ID, p.Status, p.Issuer, formatOriginPullExpiry(p.ExpiresOn)})
+	}
+	writeTable(output, "ID", "Status", "Issuer", "Expires On")
+	return nil
+}
+
+func writeOriginPullHostnames(c *cobra.Command, hostnames []originPullCertificate) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(hostnames))
+		for _, p := range hostnames {
+			raw = append(raw, p.raw)
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(hostnames))
+	for _, p := range hostnames {
+		output = append(output, []string{p.Hostname, p.CertID, formatOriginPullEnabled(p.Enabled), p.Status, p.CertStatus, formatOriginPullExpiry(p.ExpiresOn)})
+	}
+	writeTable(output, "Hostname", "Certificate", "Enabled", "Status", "Certificate Status", "Expires On")
+	return nil
+}
+
+func originPullsSetting(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	value, _ := c.Flags().GetString("value")
+	if c.Flags().Changed("value") && value != "on" && value != "off" {
+		return fmt.Errorf("invalid value %q: must be on or off", value)
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var enabled bool
+	var raw string
+	if c.Flags().Changed("value") {
+		res, err := client.OriginTLSClientAuth.Settings.Update(c.Context(), origin_tls_client_auth.SettingUpdateParams{
+			ZoneID:  cloudflare.F(zoneID),
+			Enabled: cloudflare.F(value == "on"),
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating authenticated origin pulls: %w", err)
+		}
+		enabled, raw = res.Enabled, res.JSON.RawJSON()
+	} else {
+		res, err := client.OriginTLSClientAuth.Settings.Get(c.Context(), origin_tls_client_auth.SettingGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting authenticated origin pulls: %w", err)
+		}
+		enabled, raw = res.Enabled, res.JSON.RawJSON()
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(raw))
+	}
+	writeTable([][]string{{zoneName, formatBool(enabled)}}, "Zone", "Authenticated Origin Pulls")
+	return nil
+}
+
+func originPullsCertsList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var certs []originPullCertificate
+	iter := client.OriginTLSClientAuth.ListAutoPaging(c.Context(), origin_tls_client_auth.OriginTLSClientAuthListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		p, err := originPullCertificateFromRaw(iter.Current().JSON.RawJSON())
+		if err != nil {
+			return err
+		}
+		certs = append(certs, p)
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing origin pull certificates: %w", err)
+	}
+	return writeOriginPullCertificates(c, certs)
+}
+
+func originPullsCertsUpload(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "cert", "key"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	certPEM, keyPEM, err := loadClientCertificate(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	res, err := client.OriginTLSClientAuth.New(c.Context(), origin_tls_client_auth.OriginTLSClientAuthNewParams{
+		ZoneID:      cloudflare.F(zoneID),
+		Certificate: cloudflare.F(certPEM),
+		PrivateKey:  cloudflare.F(keyPEM),
+	})
+	if err != nil {
+		return fmt.Errorf("Error uploading origin pull certificate: %w", err)
+	}
+	p, err := originPullCertificateFromRaw(res.JSON.RawJSON())
+	if err != nil {
+		return err
+	}
+	return writeOriginPullCertificates(c, []originPullCertificate{p})
+}
+
+func originPullsCertsDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.OriginTLSClientAuth.Delete(c.Context(), id, origin_tls_client_auth.OriginTLSClientAuthDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting origin pull certificate: %w", err)
+	}
+	fmt.Printf("Deleted origin pull certificate %s\n", id)
+	return nil
+}
+
+func originPullsHostnamesGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "hostname"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	hostname, _ := c.Flags().GetString("hostname")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.OriginTLSClientAuth.Hostnames.Get(c.Context(), hostname, origin_tls_client_auth.HostnameGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting origin pull setting of %s: %w", hostname, err)
+	}
+	p, err := originPullCertificateFromRaw(res.JSON.RawJSON())
+	if err != nil {
+		return err
+	}
+	return writeOriginPullHostnames(c, []originPullCertificate{p})
+}
+
+// updateOriginPullHostnames sends the per-hostname configuration and shows
+// the result.
+func updateOriginPullHostnames(c *cobra.Command, zoneID string, config []origin_tls_client_auth.HostnameUpdateParamsConfig) error {
+	var hostnames []originPullCertificate
+	iter := client.OriginTLSClientAuth.Hostnames.UpdateAutoPaging(c.Context(), origin_tls_client_auth.HostnameUpdateParams{
+		ZoneID: cloudflare.F(zoneID),
+		Config: cloudflare.F(config),
+	})
+	for iter.Next() {
+		p, err := originPullCertificateFromRaw(iter.Current().JSON.RawJSON())
+		if err != nil {
+			return err
+		}
+		hostnames = append(hostnames, p)
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error updating per-hostname origin pulls: %w", err)
+	}
+	return writeOriginPullHostnames(c, hostnames)
+}
+
+func originPullsHostnamesSet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "cert-id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	hostnames, _ := c.Flags().GetStringArray("hostname")
+	certID, _ := c.Flags().GetString("cert-id")
+	value, _ := c.Flags().GetString("value")
+	if len(hostnames) == 0 {
+		return errors.New("at least one --hostname is required")
+	}
+	if value != "on" && value != "off" {
+		return fmt.Errorf("invalid value %q: must be on or off", value)
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	config := make([]origin_tls_client_auth.HostnameUpdateParamsConfig, 0, len(hostnames))
+	for _, h := range hostnames {
+		config = append(config, origin_tls_client_auth.HostnameUpdateParamsConfig{
+			Hostname: cloudflare.F(h),
+			CERTID:   cloudflare.F(certID),
+			Enabled:  cloudflare.F(value == "on"),
+		})
+	}
+	return updateOriginPullHostnames(c, zoneID, config)
+}
+
+// originPullsHostnamesRemove voids the associations, which the API does for
+// a null enabled value.
+func originPullsHostnamesRemove(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	hostnames, _ := c.Flags().GetStringArray("hostname")
+	if len(hostnames) == 0 {
+		return errors.New("at least one --hostname is required")
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	config := make([]origin_tls_client_auth.HostnameUpdateParamsConfig, 0, len(hostnames))
+	for _, h := range hostnames {
+		current, err := client.OriginTLSClientAuth.Hostnames.Get(c.Context(), h, origin_tls_client_auth.HostnameGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting origin pull setting of %s: %w", h, err)
+		}
+		config = append(config, origin_tls_client_auth.HostnameUpdateParamsConfig{
+			Hostname: cloudflare.F(h),
+			CERTID:   cloudflare.F(current.CERTID),
+			Enabled:  cloudflare.Null[bool](),
+		})
+	}
+	return updateOriginPullHostnames(c, zoneID, config)
+}
+
+func originPullsHostnamesCertsList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var certs []originPullCertificate
+	iter := client.OriginTLSClientAuth.Hostnames.Certificates.ListAutoPaging(c.Context(), origin_tls_client_auth.HostnameCertificateListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		p, err := originPullCertificateFromRaw(iter.Current().JSON.RawJSON())
+		if err != nil {
+			return err
+		}
+		certs = append(certs, p)
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing per-hostname origin pull certificates: %w", err)
+	}
+	return writeOriginPullCertificates(c, certs)
+}
+
+func originPullsHostnamesCertsUpload(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "cert", "key"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	certPEM, keyPEM, err := loadClientCertificate(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	res, err := client.OriginTLSClientAuth.Hostnames.Certificates.New(c.Context(), origin_tls_client_auth.HostnameCertificateNewParams{
+		ZoneID:      cloudflare.F(zoneID),
+		Certificate: cloudflare.F(certPEM),
+		PrivateKey:  cloudflare.F(keyPEM),
+	})
+	if err != nil {
+		return fmt.Errorf("Error uploading per-hostname origin pull certificate: %w", err)
+	}
+	p, err := originPullCertificateFromRaw(res.JSON.RawJSON())
+	if err != nil {
+		return err
+	}
+	return writeOriginPullCertificates(c, []originPullCertificate{p})
+}
+
+func originPullsHostnamesCertsDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.OriginTLSClientAuth.Hostnames.Certificates.Delete(c.Context(), id, origin_tls_client_auth.HostnameCertificateDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting per-hostname origin pull certificate: %w", err)
+	}
+	fmt.Printf("Deleted per-hostname origin pull certificate %s\n", id)
+	return nil
+}
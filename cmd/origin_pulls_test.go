@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestCheckClientCertificate(t *testing.T) {
+	tests := []struct {
+		usage []x509.ExtKeyUsage
+		ok    bool
+	}{
+		{nil, true},
+		{[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, true},
+		{[]x509.ExtKeyUsage{x509.ExtKeyUsageAny}, true},
+		{[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, false},
+	}
+	for _, tt := range tests {
+		err := checkClientCertificate(&x509.Certificate{ExtKeyUsage: tt.usage})
+		if (err == nil) != tt.ok {
+			t.Errorf("checkClientCertificate(%v) = %v; want ok %t", tt.usage, err, tt.ok)
+		}
+	}
+}
+
+func TestOriginPullCertificateFromRaw(t *testing.T) {
+	p, err := originPullCertificateFromRaw(`{"hostname":"app.example.com","cert_id":"2458ce5a-0c35-4c7f-82c7-8e9487d3ff60","enabled":null,"status":"active","expires_on":"2100-01-01T05:20:00.12345Z"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Hostname != "app.example.com" || p.Enabled != nil || formatOriginPullEnabled(p.Enabled) != "" {
+		t.Errorf("originPullCertificateFromRaw() = %+v", p)
+	}
+
+	p, err = originPullCertificateFromRaw(`{"hostname":"app.example.com","enabled":false}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatOriginPullEnabled(p.Enabled); got != "false" {
+		t.Errorf("formatOriginPullEnabled() = %q; want false", got)
+	}
+	if got := formatOriginPullExpiry(p.ExpiresOn); got != "" {
+		t.Errorf("formatOriginPullExpiry() = %q; want empty", got)
+	}
+}
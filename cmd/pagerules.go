@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -29,10 +31,287 @@ var pageRulesListCmd = &cobra.Command{
 	},
 }
 
+var pageRulesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a Page Rule",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return createPageRule(cmd)
+	},
+}
+
+var pageRulesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a Page Rule",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updatePageRule(cmd)
+	},
+}
+
+var pageRulesDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a Page Rule",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deletePageRule(cmd)
+	},
+}
+
+var pageRulesReorderCmd = &cobra.Command{
+	Use:   "reorder",
+	Short: "Change the priority of a Page Rule",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reorderPageRule(cmd)
+	},
+}
+
+// pageRuleActionTypes lists the actions that have their own flag, named after
+// the action ID with dashes. Actions with no values are enabled by a boolean
+// flag; the rest take a value, checked against values when given.
+var pageRuleActionTypes = []struct {
+	id     page_rules.PageRuleActionsID
+	kind   string
+	values []string
+}{
+	{page_rules.PageRuleActionsIDForwardingURL, "forward", nil},
+	{page_rules.PageRuleActionsIDAlwaysUseHTTPS, "bool", nil},
+	{page_rules.PageRuleActionsIDAutomaticHTTPSRewrites, "onoff", nil},
+	{page_rules.PageRuleActionsIDBrowserCacheTTL, "ttl", nil},
+	{page_rules.PageRuleActionsIDBrowserCheck, "onoff", nil},
+	{page_rules.PageRuleActionsIDBypassCacheOnCookie, "string", nil},
+	{page_rules.PageRuleActionsIDCacheByDeviceType, "onoff", nil},
+	{page_rules.PageRuleActionsIDCacheDeceptionArmor, "onoff", nil},
+	{page_rules.PageRuleActionsIDCacheLevel, "string", []string{"bypass", "basic", "simplified", "aggressive", "cache_everything"}},
+	{page_rules.PageRuleActionsIDCacheOnCookie, "string", nil},
+	{page_rules.PageRuleActionsIDDisableApps, "bool", nil},
+	{page_rules.PageRuleActionsIDDisablePerformance, "bool", nil},
+	{page_rules.PageRuleActionsIDDisableSecurity, "bool", nil},
+	{page_rules.PageRuleActionsIDDisableZaraz, "bool", nil},
+	{page_rules.PageRuleActionsIDEdgeCacheTTL, "ttl", nil},
+	{page_rules.PageRuleActionsIDEmailObfuscation, "onoff", nil},
+	{page_rules.PageRuleActionsIDExplicitCacheControl, "onoff", nil},
+	{page_rules.PageRuleActionsIDHostHeaderOverride, "string", nil},
+	{page_rules.PageRuleActionsIDIPGeolocation, "onoff", nil},
+	{page_rules.PageRuleActionsIDMirage, "onoff", nil},
+	{page_rules.PageRuleActionsIDOpportunisticEncryption, "onoff", nil},
+	{page_rules.PageRuleActionsIDOriginErrorPagePassThru, "onoff", nil},
+	{page_rules.PageRuleActionsIDPolish, "string", []string{"off", "lossless", "lossy"}},
+	{page_rules.PageRuleActionsIDResolveOverride, "string", nil},
+	{page_rules.PageRuleActionsIDRespectStrongEtag, "onoff", nil},
+	{page_rules.PageRuleActionsIDResponseBuffering, "onoff", nil},
+	{page_rules.PageRuleActionsIDRocketLoader, "onoff", nil},
+	{page_rules.PageRuleActionsIDSecurityLevel, "string", []string{"off", "essentially_off", "low", "medium", "high", "under_attack"}},
+	{page_rules.PageRuleActionsIDSortQueryStringForCache, "onoff", nil},
+	{page_rules.PageRuleActionsIDSSL, "string", []string{"off", "flexible", "full", "strict"}},
+	{page_rules.PageRuleActionsIDTrueClientIPHeader, "onoff", nil},
+	{page_rules.PageRuleActionsIDWAF, "onoff", nil},
+}
+
+func pageRuleActionFlag(id page_rules.PageRuleActionsID) string {
+	return strings.ReplaceAll(string(id), "_", "-")
+}
+
 func init() {
 	rootCmd.AddCommand(pageRulesCmd)
 	pageRulesCmd.AddCommand(pageRulesListCmd)
+	pageRulesCmd.AddCommand(pageRulesCreateCmd)
+	pageRulesCmd.AddCommand(pageRulesUpdateCmd)
+	pageRulesCmd.AddCommand(pageRulesDeleteCmd)
+	pageRulesCmd.AddCommand(pageRulesReorderCmd)
 	pageRulesListCmd.Flags().String("zone", "", "zone name")
+
+	addPageRuleFlags(pageRulesCreateCmd)
+
+	addPageRuleFlags(pageRulesUpdateCmd)
+	pageRulesUpdateCmd.Flags().String("id", "", "page rule ID")
+	pageRulesUpdateCmd.Flags().StringArray("remove-action", nil, "ID of an action to remove, e.g. cache_level (repeatable)")
+
+	pageRulesDeleteCmd.Flags().String("zone", "", "zone name")
+	pageRulesDeleteCmd.Flags().String("id", "", "page rule ID")
+
+	pageRulesReorderCmd.Flags().String("zone", "", "zone name")
+	pageRulesReorderCmd.Flags().String("id", "", "page rule ID")
+	pageRulesReorderCmd.Flags().Int64("priority", 0, "new priority; higher priorities take precedence")
+}
+
+func addPageRuleFlags(c *cobra.Command) {
+	c.Flags().String("zone", "", "zone name")
+	c.Flags().String("url", "", "URL pattern, e.g. example.com/images/*")
+	c.Flags().Int64("priority", 0, "priority; higher priorities take precedence")
+	c.Flags().String("status", "active", "active or disabled")
+	c.Flags().StringArray("action", nil, "any action as id=value; the value may be JSON (repeatable)")
+
+	for _, a := range pageRuleActionTypes {
+		flag := pageRuleActionFlag(a.id)
+		switch a.kind {
+		case "forward":
+			c.Flags().String(flag, "", "redirect as STATUS,URL, e.g. 301,https://example.com/$1")
+		case "bool":
+			c.Flags().Bool(flag, false, "enable the "+string(a.id)+" action")
+		case "ttl":
+			c.Flags().Duration(flag, 0, "set "+string(a.id))
+		case "onoff":
+			c.Flags().String(flag, "", "set "+string(a.id)+": on or off")
+		default:
+			usage := "set " + string(a.id)
+			if len(a.values) > 0 {
+				usage += ": " + strings.Join(a.values, ", ")
+			}
+			c.Flags().String(flag, "", usage)
+		}
+	}
+}
+
+// pageRuleActionsFromFlags builds the actions given on the command line. A
+// boolean action flag set to false is returned with the false flag in remove.
+func pageRuleActionsFromFlags(c *cobra.Command) (actions []page_rules.PageRuleAction, remove []page_rules.PageRuleActionsID, err error) {
+	for _, a := range pageRuleActionTypes {
+		flag := pageRuleActionFlag(a.id)
+		if !c.Flags().Changed(flag) {
+			continue
+		}
+
+		var value interface{}
+		switch a.kind {
+		case "forward":
+			s, _ := c.Flags().GetString(flag)
+			status, url, ok := strings.Cut(s, ",")
+			code, convErr := strconv.Atoi(strings.TrimSpace(status))
+			if !ok || convErr != nil || (code != 301 && code != 302) || url == "" {
+				return nil, nil, fmt.Errorf("invalid --%s %q: must be 301|302,URL", flag, s)
+			}
+			value = map[string]interface{}{"status_code": code, "url": strings.TrimSpace(url)}
+		case "bool":
+			if on, _ := c.Flags().GetBool(flag); !on {
+				remove = append(remove, a.id)
+				continue
+			}
+		case "ttl":
+			d, _ := c.Flags().GetDuration(flag)
+			secs, err := durationSeconds(flag, d)
+			if err != nil {
+				return nil, nil, err
+			}
+			value = secs
+		case "onoff":
+			s, _ := c.Flags().GetString(flag)
+			if s != "on" && s != "off" {
+				return nil, nil, fmt.Errorf("invalid --%s %q: must be on or off", flag, s)
+			}
+			value = s
+		default:
+			s, _ := c.Flags().GetString(flag)
+			if len(a.values) > 0 && !slices.Contains(a.values, s) {
+				return nil, nil, fmt.Errorf("invalid --%s %q: must be one of %s", flag, s, strings.Join(a.values, ", "))
+			}
+			value = s
+		}
+		actions = append(actions, page_rules.PageRuleAction{ID: a.id, Value: value})
+	}
+
+	generic, _ := c.Flags().GetStringArray("action")
+	for _, g := range generic {
+		id, raw, _ := strings.Cut(g, "=")
+		a := page_rules.PageRuleAction{ID: page_rules.PageRuleActionsID(id)}
+		if !a.ID.IsKnown() {
+			return nil, nil, fmt.Errorf("unknown page rule action %q", id)
+		}
+		if raw != "" {
+			if err := json.Unmarshal([]byte(raw), &a.Value); err != nil {
+				a.Value = raw
+			}
+		}
+		actions = append(actions, a)
+	}
+	return actions, remove, nil
+}
+
+// rawPageRuleActions returns the actions of an existing rule with their values
+// decoded from the raw JSON, so that they can be sent back unchanged.
+func rawPageRuleActions(r page_rules.PageRule) []page_rules.PageRuleAction {
+	actions := make([]page_rules.PageRuleAction, 0, len(r.Actions))
+	for _, a := range r.Actions {
+		action := page_rules.PageRuleAction{ID: a.ID}
+		if raw := a.JSON.Value.Raw(); raw != "" && !a.JSON.Value.IsNull() {
+			_ = json.Unmarshal([]byte(raw), &action.Value)
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// mergePageRuleActions replaces or adds the changed actions and drops the
+// removed ones, keeping the order of the existing actions.
+func mergePageRuleActions(existing, changed []page_rules.PageRuleAction, remove []page_rules.PageRuleActionsID) []page_rules.PageRuleAction {
+	merged := make([]page_rules.PageRuleAction, 0, len(existing)+len(changed))
+	for _, a := range existing {
+		if slices.Contains(remove, a.ID) {
+			continue
+		}
+		for _, ch := range changed {
+			if ch.ID == a.ID {
+				a = ch
+				break
+			}
+		}
+		merged = append(merged, a)
+	}
+	for _, ch := range changed {
+		if !slices.ContainsFunc(merged, func(a page_rules.PageRuleAction) bool { return a.ID == ch.ID }) {
+			merged = append(merged, ch)
+		}
+	}
+	return merged
+}
+
+func pageRuleTargets(url string) []page_rules.TargetParam {
+	return []page_rules.TargetParam{{
+		Target: cloudflare.F(page_rules.TargetTargetURL),
+		Constraint: cloudflare.F(page_rules.TargetConstraintParam{
+			Operator: cloudflare.F(page_rules.TargetConstraintOperatorMatches),
+			Value:    cloudflare.F(url),
+		}),
+	}}
+}
+
+func checkPageRuleStatus(status string) error {
+	if status != string(page_rules.PageRuleStatusActive) && status != string(page_rules.PageRuleStatusDisabled) {
+		return fmt.Errorf("invalid status %q: must be active or disabled", status)
+	}
+	return nil
+}
+
+func formatPageRule(r page_rules.PageRule) []string {
+	var targets []string
+	for _, t := range r.Targets {
+		targets = append(targets, t.Constraint.Value)
+	}
+	var actions []string
+	for _, a := range r.Actions {
+		actions = append(actions, formatAction(a))
+	}
+	return []string{
+		strconv.FormatInt(r.Priority, 10),
+		r.ID,
+		string(r.Status),
+		strings.Join(targets, ", "),
+		strings.Join(actions, ", "),
+	}
+}
+
+func writePageRules(c *cobra.Command, rules []page_rules.PageRule) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(rules))
+		for _, r := range rules {
+			raw = append(raw, json.RawMessage(r.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(rules))
+	for _, r := range rules {
+		output = append(output, formatPageRule(r))
+	}
+	writeTable(output, "Priority", "ID", "Status", "URL", "Actions")
+	return nil
 }
 
 func listPageRules(c *cobra.Command) error {
@@ -56,26 +335,180 @@ func listPageRules(c *cobra.Command) error {
 		return fmt.Errorf("no rules returned")
 	}
 
-	fmt.Printf("%3s %-32s %-8s %s\n", "Pri", "ID", "Status", "URL")
-	for _, r := range *rules {
-		var settings []string
+	return writePageRules(c, *rules)
+}
+
+func createPageRule(c *cobra.Command) error {
+	if err := checkFlags(c, "zone", "url"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	url, _ := c.Flags().GetString("url")
+	status, _ := c.Flags().GetString("status")
+
+	if err := checkPageRuleStatus(status); err != nil {
+		return err
+	}
+	actions, _, err := pageRuleActionsFromFlags(c)
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		return fmt.Errorf("a page rule needs at least one action")
+	}
 
-		var urlPattern string
-		if len(r.Targets) > 0 {
-			urlPattern = r.Targets[0].Constraint.Value
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := page_rules.PageRuleNewParams{
+		ZoneID:  cloudflare.F(zoneID),
+		Targets: cloudflare.F(pageRuleTargets(url)),
+		Status:  cloudflare.F(page_rules.PageRuleNewParamsStatus(status)),
+	}
+	newActions := make([]page_rules.PageRuleNewParamsActionUnion, 0, len(actions))
+	for _, a := range actions {
+		action := page_rules.PageRuleNewParamsAction{ID: cloudflare.F(page_rules.PageRuleNewParamsActionsID(a.ID))}
+		if a.Value != nil {
+			action.Value = cloudflare.F(a.Value)
 		}
+		newActions = append(newActions, action)
+	}
+	params.Actions = cloudflare.F(newActions)
+	if c.Flags().Changed("priority") {
+		priority, _ := c.Flags().GetInt64("priority")
+		params.Priority = cloudflare.F(priority)
+	}
+
+	r, err := client.PageRules.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error creating page rule: %w", err)
+	}
+	return writePageRules(c, []page_rules.PageRule{*r})
+}
 
-		fmt.Printf("%3d %s %-8s %s\n", r.Priority, r.ID, r.Status, urlPattern)
-		for _, a := range r.Actions {
-			s := formatAction(a)
-			settings = append(settings, s)
+func updatePageRule(c *cobra.Command) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+	removeIDs, _ := c.Flags().GetStringArray("remove-action")
+
+	changed, remove, err := pageRuleActionsFromFlags(c)
+	if err != nil {
+		return err
+	}
+	for _, r := range removeIDs {
+		remove = append(remove, page_rules.PageRuleActionsID(r))
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.PageRules.Get(c.Context(), id, page_rules.PageRuleGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting page rule: %w", err)
+	}
+
+	actions := mergePageRuleActions(rawPageRuleActions(*existing), changed, remove)
+	if len(actions) == 0 {
+		return fmt.Errorf("a page rule needs at least one action")
+	}
+
+	url := ""
+	if len(existing.Targets) > 0 {
+		url = existing.Targets[0].Constraint.Value
+	}
+	if c.Flags().Changed("url") {
+		url, _ = c.Flags().GetString("url")
+	}
+	status := string(existing.Status)
+	if c.Flags().Changed("status") {
+		status, _ = c.Flags().GetString("status")
+		if err := checkPageRuleStatus(status); err != nil {
+			return err
+		}
+	}
+	priority := existing.Priority
+	if c.Flags().Changed("priority") {
+		priority, _ = c.Flags().GetInt64("priority")
+	}
+
+	updateActions := make([]page_rules.PageRuleUpdateParamsActionUnion, 0, len(actions))
+	for _, a := range actions {
+		action := page_rules.PageRuleUpdateParamsAction{ID: cloudflare.F(page_rules.PageRuleUpdateParamsActionsID(a.ID))}
+		if a.Value != nil {
+			action.Value = cloudflare.F(a.Value)
 		}
-		fmt.Println("   ", strings.Join(settings, ", "))
+		updateActions = append(updateActions, action)
+	}
+
+	r, err := client.PageRules.Update(c.Context(), id, page_rules.PageRuleUpdateParams{
+		ZoneID:   cloudflare.F(zoneID),
+		Actions:  cloudflare.F(updateActions),
+		Targets:  cloudflare.F(pageRuleTargets(url)),
+		Priority: cloudflare.F(priority),
+		Status:   cloudflare.F(page_rules.PageRuleUpdateParamsStatus(status)),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating page rule: %w", err)
+	}
+	return writePageRules(c, []page_rules.PageRule{*r})
+}
+
+func deletePageRule(c *cobra.Command) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
 	}
 
+	_, err = client.PageRules.Delete(c.Context(), id, page_rules.PageRuleDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting page rule: %w", err)
+	}
 	return nil
 }
 
+func reorderPageRule(c *cobra.Command) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	if !c.Flags().Changed("priority") {
+		return fmt.Errorf("error: the required flag %q was empty or not provided", "priority")
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+	priority, _ := c.Flags().GetInt64("priority")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	r, err := client.PageRules.Edit(c.Context(), id, page_rules.PageRuleEditParams{
+		ZoneID:   cloudflare.F(zoneID),
+		Priority: cloudflare.F(priority),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating page rule: %w", err)
+	}
+	return writePageRules(c, []page_rules.PageRule{*r})
+}
+
 func formatAction(a page_rules.PageRuleAction) string {
 	idStr := formatID(string(a.ID))
 
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/page_rules"
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var pageRulesMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Translate Page Rules into redirect, cache, config and origin rules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migratePageRules(cmd)
+	},
+}
+
+func init() {
+	pageRulesCmd.AddCommand(pageRulesMigrateCmd)
+	pageRulesMigrateCmd.Flags().String("zone", "", "zone name")
+	pageRulesMigrateCmd.Flags().String("id", "", "only migrate this page rule")
+	pageRulesMigrateCmd.Flags().Bool("apply", false, "create the translated rules instead of only printing them; rules migrated before are skipped")
+}
+
+// migratedRule is a Rules engine rule translated from a page rule.
+type migratedRule struct {
+	PageRuleID string          `json:"page_rule_id"`
+	Phase      rulesets.Phase  `json:"phase"`
+	Rule       json.RawMessage `json:"rule"`
+	rule       rulesetRule
+}
+
+// pageRuleMigration is the result of translating one page rule.
+type pageRuleMigration struct {
+	PageRuleID string         `json:"page_rule_id"`
+	Rules      []migratedRule `json:"rules"`
+	Notes      []string       `json:"notes,omitempty"`
+}
+
+// quoteRulesString quotes s as a Rules language string literal.
+func quoteRulesString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// pageRuleWildcard turns a page rule URL pattern into a pattern for the
+// wildcard operator on http.request.full_uri. Patterns without a scheme match
+// both http and https, which takes an extra "*" in front; shift is the number
+// of such extra wildcards, used to renumber $N references in redirects.
+func pageRuleWildcard(pattern string) (wildcard string, shift int) {
+	if !strings.HasPrefix(pattern, "http://") && !strings.HasPrefix(pattern, "https://") {
+		pattern = "*://" + pattern
+		shift = 1
+	}
+	_, rest, _ := strings.Cut(pattern, "://")
+	if !strings.Contains(rest, "/") {
+		pattern += "/"
+	}
+	return pattern, shift
+}
+
+var pageRulePlaceholder = regexp.MustCompile(`\$(\d)`)
+
+// pageRuleRedirectTarget returns the target_url of a redirect rule for a
+// forwarding URL, using wildcard_replace when it refers to matched parts.
+func pageRuleRedirectTarget(wildcard string, shift int, url string) map[string]interface{} {
+	if !pageRulePlaceholder.MatchString(url) {
+		return map[string]interface{}{"value": url}
+	}
+	target := pageRulePlaceholder.ReplaceAllStringFunc(url, func(m string) string {
+		n, _ := strconv.Atoi(m[1:])
+		return "${" + strconv.Itoa(n+shift) + "}"
+	})
+	return map[string]interface{}{
+		"expression": fmt.Sprintf("wildcard_replace(http.request.full_uri, %s, %s)", quoteRulesString(wildcard), quoteRulesString(target)),
+	}
+}
+
+// pageRuleTTL returns a cache rule TTL setting for a page rule TTL in seconds.
+func pageRuleTTL(v interface{}) map[string]interface{} {
+	secs, _ := v.(float64)
+	if secs <= 0 {
+		return map[string]interface{}{"mode": "respect_origin"}
+	}
+	return map[string]interface{}{"mode": "override_origin", "default": int64(secs)}
+}
+
+// pageRuleConfigKeys maps page rule actions to set_config parameters taking
+// an on/off value as a boolean.
+var pageRuleConfigKeys = map[page_rules.PageRuleActionsID]string{
+	page_rules.PageRuleActionsIDAutomaticHTTPSRewrites:  "automatic_https_rewrites",
+	page_rules.PageRuleActionsIDBrowserCheck:            "bic",
+	page_rules.PageRuleActionsIDEmailObfuscation:        "email_obfuscation",
+	page_rules.PageRuleActionsIDMirage:                  "mirage",
+	page_rules.PageRuleActionsIDOpportunisticEncryption: "opportunistic_encryption",
+	page_rules.PageRuleActionsIDRocketLoader:            "rocket_loader",
+}
+
+// translatePageRule translates a page rule into the equivalent rules of the
+// redirect, cache, config and origin phases. Actions that have no equivalent
+// are reported as notes.
+func translatePageRule(id, pattern string, enabled bool, actions []page_rules.PageRuleAction) pageRuleMigration {
+	m := pageRuleMigration{PageRuleID: id}
+	wildcard, shift := pageRuleWildcard(pattern)
+	match := "http.request.full_uri wildcard " + quoteRulesString(wildcard)
+	description := fmt.Sprintf("Migrated from page rule %s (%s)", id, pattern)
+
+	cacheParams := map[string]interface{}{}
+	cacheKey := map[string]interface{}{}
+	configParams := map[string]interface{}{}
+	originParams := map[string]interface{}{}
+
+	add := func(phase rulesets.Phase, action rulesets.RulesetGetResponseRulesAction, expression string, params interface{}) {
+		m.Rules = append(m.Rules, migratedRule{
+			PageRuleID: id,
+			Phase:      phase,
+			rule: rulesetRule{
+				Action:           string(action),
+				ActionParameters: params,
+				Description:      description,
+				Expression:       expression,
+				Enabled:          enabled,
+			},
+		})
+	}
+
+	for _, a := range actions {
+		value, _ := a.Value.(string)
+		switch a.ID {
+		case page_rules.PageRuleActionsIDForwardingURL:
+			v, _ := a.Value.(map[string]interface{})
+			url, _ := v["url"].(string)
+			status, _ := v["status_code"].(float64)
+			add(rulesets.PhaseHTTPRequestDynamicRedirect, rulesets.RulesetGetResponseRulesActionRedirect, match, map[string]interface{}{
+				"from_value": map[string]interface{}{
+					"status_code":           int(status),
+					"target_url":            pageRuleRedirectTarget(wildcard, shift, url),
+					"preserve_query_string": !strings.Contains(url, "?"),
+				},
+			})
+		case page_rules.PageRuleActionsIDAlwaysUseHTTPS:
+			add(rulesets.PhaseHTTPRequestDynamicRedirect, rulesets.RulesetGetResponseRulesActionRedirect, "("+match+") and not ssl", map[string]interface{}{
+				"from_value": map[string]interface{}{
+					"status_code": 301,
+					"target_url": map[string]interface{}{
+						"expression": `concat("https://", http.host, http.request.uri)`,
+					},
+				},
+			})
+		case page_rules.PageRuleActionsIDCacheLevel:
+			switch value {
+			case "bypass":
+				cacheParams["cache"] = false
+			case "cache_everything":
+				cacheParams["cache"] = true
+			case "simplified":
+				cacheKey["custom_key"] = map[string]interface{}{
+					"query_string": map[string]interface{}{"exclude": map[string]interface{}{"all": true}},
+				}
+			default:
+				m.Notes = append(m.Notes, fmt.Sprintf("cache_level %s is the default cache behaviour and needs no rule", value))
+			}
+		case page_rules.PageRuleActionsIDEdgeCacheTTL:
+			cacheParams["edge_ttl"] = pageRuleTTL(a.Value)
+		case page_rules.PageRuleActionsIDBrowserCacheTTL:
+			cacheParams["browser_ttl"] = pageRuleTTL(a.Value)
+		case page_rules.PageRuleActionsIDCacheByDeviceType:
+			cacheKey["cache_by_device_type"] = value == "on"
+		case page_rules.PageRuleActionsIDCacheDeceptionArmor:
+			cacheKey["cache_deception_armor"] = value == "on"
+		case page_rules.PageRuleActionsIDRespectStrongEtag:
+			cacheParams["respect_strong_etags"] = value == "on"
+		case page_rules.PageRuleActionsIDOriginErrorPagePassThru:
+			cacheParams["origin_error_page_passthru"] = value == "on"
+		case page_rules.PageRuleActionsIDSSL, page_rules.PageRuleActionsIDSecurityLevel, page_rules.PageRuleActionsIDPolish:
+			configParams[string(a.ID)] = value
+		case page_rules.PageRuleActionsIDDisableApps:
+			configParams["disable_apps"] = true
+		case page_rules.PageRuleActionsIDDisableZaraz:
+			configParams["disable_zaraz"] = true
+		case page_rules.PageRuleActionsIDDisablePerformance:
+			configParams["rocket_loader"] = false
+			configParams["mirage"] = false
+			configParams["polish"] = "off"
+		case page_rules.PageRuleActionsIDHostHeaderOverride:
+			originParams["host_header"] = value
+		case page_rules.PageRuleActionsIDResolveOverride:
+			originParams["origin"] = map[string]interface{}{"host": value}
+		default:
+			if key, ok := pageRuleConfigKeys[a.ID]; ok {
+				configParams[key] = value == "on"
+				continue
+			}
+			m.Notes = append(m.Notes, fmt.Sprintf("%s has no equivalent rule and was not migrated", a.ID))
+		}
+	}
+
+	if len(cacheKey) > 0 {
+		cacheParams["cache_key"] = cacheKey
+	}
+	if len(cacheParams) > 0 {
+		// cache is only set for cache_level bypass or cache_everything; without
+		// it the rule keeps the zone's cache eligibility, as the page rule did.
+		add(rulesets.PhaseHTTPRequestCacheSettings, rulesets.RulesetGetResponseRulesActionSetCacheSettings, match, cacheParams)
+	}
+	if len(configParams) > 0 {
+		add(rulesets.PhaseHTTPConfigSettings, rulesets.RulesetGetResponseRulesActionSetConfig, match, configParams)
+	}
+	if len(originParams) > 0 {
+		add(rulesets.PhaseHTTPRequestOrigin, rulesets.RulesetGetResponseRulesActionRoute, match, originParams)
+	}
+
+	for i := range m.Rules {
+		m.Rules[i].Rule, _ = json.Marshal(m.Rules[i].rule.phaseParams())
+	}
+	return m
+}
+
+func migratePageRules(c *cobra.Command) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	onlyID, _ := c.Flags().GetString("id")
+	apply, _ := c.Flags().GetBool("apply")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rules, err := client.PageRules.List(c.Context(), page_rules.PageRuleListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The first matching page rule wins, so the highest priority comes first.
+	list := *rules
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Priority > list[j].Priority })
+
+	var migrations []pageRuleMigration
+	for _, r := range list {
+		if onlyID != "" && r.ID != onlyID {
+			continue
+		}
+		pattern := ""
+		if len(r.Targets) > 0 {
+			pattern = r.Targets[0].Constraint.Value
+		}
+		migrations = append(migrations, translatePageRule(r.ID, pattern, r.Status == page_rules.PageRuleStatusActive, rawPageRuleActions(r)))
+	}
+	if onlyID != "" && len(migrations) == 0 {
+		return fmt.Errorf("page rule %q not found", onlyID)
+	}
+
+	var created []migratedRule
+	var skipped int
+	if apply {
+		// Redirects stop at the first match like page rules did, but in the
+		// other phases later rules override earlier ones, so those are
+		// added lowest priority first.
+		var ordered []migratedRule
+		for _, m := range migrations {
+			for _, r := range m.Rules {
+				if r.Phase == rulesets.PhaseHTTPRequestDynamicRedirect {
+					ordered = append(ordered, r)
+				}
+			}
+		}
+		for i := len(migrations) - 1; i >= 0; i-- {
+			for _, r := range migrations[i].Rules {
+				if r.Phase != rulesets.PhaseHTTPRequestDynamicRedirect {
+					ordered = append(ordered, r)
+				}
+			}
+		}
+		// Rules already migrated by an earlier run are found by their
+		// description and expression, and not created again.
+		migrated := map[rulesets.Phase]map[string]bool{}
+		for _, r := range ordered {
+			existing, ok := migrated[r.Phase]
+			if !ok {
+				rs, err := getPhaseEntrypoint(c, "", zoneID, r.Phase)
+				if err != nil {
+					return err
+				}
+				existing = map[string]bool{}
+				if rs != nil {
+					for _, e := range rs.Rules {
+						existing[e.Description+"\n"+e.Expression] = true
+					}
+				}
+				migrated[r.Phase] = existing
+			}
+			if existing[r.rule.Description+"\n"+r.rule.Expression] {
+				skipped++
+				continue
+			}
+
+			if _, err := addPhaseRule(c, "", zoneID, r.Phase, r.rule, nil); err != nil {
+				for _, done := range created {
+					fmt.Fprintf(os.Stderr, "Created %s rule for page rule %s\n", done.Phase, done.PageRuleID)
+				}
+				return fmt.Errorf("Error creating %s rule for page rule %s, after creating %d rules: %w", r.Phase, r.PageRuleID, len(created), err)
+			}
+			created = append(created, r)
+		}
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(migrations)
+	}
+
+	output := make([][]string, 0)
+	for _, m := range migrations {
+		for _, r := range m.Rules {
+			params, _ := json.Marshal(r.rule.ActionParameters)
+			output = append(output, []string{m.PageRuleID, string(r.Phase), r.rule.Action, r.rule.Expression, string(params)})
+		}
+	}
+	writeTable(output, "Page Rule", "Phase", "Action", "Expression", "Parameters")
+
+	for _, m := range migrations {
+		for _, n := range m.Notes {
+			fmt.Fprintf(os.Stderr, "page rule %s: %s\n", m.PageRuleID, n)
+		}
+	}
+	if apply {
+		if skipped > 0 {
+			fmt.Printf("Skipped %d rules that were already migrated\n", skipped)
+		}
+		fmt.Printf("Created %d rules; disable or delete the migrated page rules once they are verified\n", len(created))
+	}
+	return nil
+}
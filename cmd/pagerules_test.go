@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v6/page_rules"
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/spf13/cobra"
+)
+
+func TestPageRuleActionsFromFlags(t *testing.T) {
+	c := &cobra.Command{Use: "test"}
+	addPageRuleFlags(c)
+	args := []string{
+		"--forwarding-url", "301,https://www.example.com/$1",
+		"--cache-level", "cache_everything",
+		"--edge-cache-ttl", "2h",
+		"--always-use-https=false",
+		"--action", `cache_key_fields={"host":{"resolved":true}}`,
+	}
+	if err := c.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, remove, err := pageRuleActionsFromFlags(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []page_rules.PageRuleActionsID{page_rules.PageRuleActionsIDAlwaysUseHTTPS}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("remove = %v; want %v", remove, want)
+	}
+	got := map[page_rules.PageRuleActionsID]interface{}{}
+	for _, a := range actions {
+		got[a.ID] = a.Value
+	}
+	want := map[page_rules.PageRuleActionsID]interface{}{
+		page_rules.PageRuleActionsIDForwardingURL:  map[string]interface{}{"status_code": 301, "url": "https://www.example.com/$1"},
+		page_rules.PageRuleActionsIDCacheLevel:     "cache_everything",
+		page_rules.PageRuleActionsIDEdgeCacheTTL:   int64(7200),
+		page_rules.PageRuleActionsIDCacheKeyFields: map[string]interface{}{"host": map[string]interface{}{"resolved": true}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %v; want %v", got, want)
+	}
+
+	c = &cobra.Command{Use: "test"}
+	addPageRuleFlags(c)
+	_ = c.Flags().Parse([]string{"--cache-level", "everything"})
+	if _, _, err := pageRuleActionsFromFlags(c); err == nil {
+		t.Error("pageRuleActionsFromFlags() with invalid cache level = nil error; want error")
+	}
+}
+
+func TestMergePageRuleActions(t *testing.T) {
+	existing := []page_rules.PageRuleAction{
+		{ID: page_rules.PageRuleActionsIDCacheLevel, Value: "bypass"},
+		{ID: page_rules.PageRuleActionsIDAlwaysUseHTTPS},
+		{ID: page_rules.PageRuleActionsIDSSL, Value: "full"},
+	}
+	changed := []page_rules.PageRuleAction{
+		{ID: page_rules.PageRuleActionsIDSSL, Value: "strict"},
+		{ID: page_rules.PageRuleActionsIDRocketLoader, Value: "off"},
+	}
+	got := mergePageRuleActions(existing, changed, []page_rules.PageRuleActionsID{page_rules.PageRuleActionsIDAlwaysUseHTTPS})
+	want := []page_rules.PageRuleAction{
+		{ID: page_rules.PageRuleActionsIDCacheLevel, Value: "bypass"},
+		{ID: page_rules.PageRuleActionsIDSSL, Value: "strict"},
+		{ID: page_rules.PageRuleActionsIDRocketLoader, Value: "off"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergePageRuleActions() = %v; want %v", got, want)
+	}
+}
+
+func TestTranslatePageRule(t *testing.T) {
+	actions := []page_rules.PageRuleAction{
+		{ID: page_rules.PageRuleActionsIDForwardingURL, Value: map[string]interface{}{"status_code": float64(301), "url": "https://www.example.com/$1"}},
+		{ID: page_rules.PageRuleActionsIDCacheLevel, Value: "bypass"},
+		{ID: page_rules.PageRuleActionsIDSSL, Value: "strict"},
+		{ID: page_rules.PageRuleActionsIDWAF, Value: "off"},
+	}
+	m := translatePageRule("abc", "example.com/*", true, actions)
+
+	phases := map[rulesets.Phase]rulesetRule{}
+	for _, r := range m.Rules {
+		phases[r.Phase] = r.rule
+	}
+	if len(phases) != 3 {
+		t.Fatalf("translatePageRule() phases = %v; want redirect, cache and config", phases)
+	}
+
+	redirect := phases[rulesets.PhaseHTTPRequestDynamicRedirect]
+	if want := `http.request.full_uri wildcard "*://example.com/*"`; redirect.Expression != want {
+		t.Errorf("redirect expression = %s; want %s", redirect.Expression, want)
+	}
+	target := redirect.ActionParameters.(map[string]interface{})["from_value"].(map[string]interface{})["target_url"]
+	wantTarget := map[string]interface{}{"expression": `wildcard_replace(http.request.full_uri, "*://example.com/*", "https://www.example.com/${2}")`}
+	if !reflect.DeepEqual(target, wantTarget) {
+		t.Errorf("redirect target = %v; want %v", target, wantTarget)
+	}
+
+	if got := phases[rulesets.PhaseHTTPRequestCacheSettings].ActionParameters; !reflect.DeepEqual(got, map[string]interface{}{"cache": false}) {
+		t.Errorf("cache parameters = %v", got)
+	}
+	if got := phases[rulesets.PhaseHTTPConfigSettings].ActionParameters; !reflect.DeepEqual(got, map[string]interface{}{"ssl": "strict"}) {
+		t.Errorf("config parameters = %v", got)
+	}
+	if len(m.Notes) != 1 {
+		t.Errorf("notes = %v; want one note for waf", m.Notes)
+	}
+}
+
+func TestTranslatePageRuleTTLOnly(t *testing.T) {
+	actions := []page_rules.PageRuleAction{
+		{ID: page_rules.PageRuleActionsIDEdgeCacheTTL, Value: float64(3600)},
+	}
+	m := translatePageRule("abc", "example.com/*", true, actions)
+	if len(m.Rules) != 1 || m.Rules[0].Phase != rulesets.PhaseHTTPRequestCacheSettings {
+		t.Fatalf("translatePageRule() rules = %v; want one cache rule", m.Rules)
+	}
+	params := m.Rules[0].rule.ActionParameters.(map[string]interface{})
+	if _, ok := params["cache"]; ok {
+		t.Errorf("cache parameters = %v; want no cache eligibility change", params)
+	}
+	if _, ok := params["edge_ttl"]; !ok {
+		t.Errorf("cache parameters = %v; want edge_ttl", params)
+	}
+}
+
+func TestPageRuleWildcard(t *testing.T) {
+	tests := []struct {
+		pattern, want string
+		shift         int
+	}{
+		{"example.com", "*://example.com/", 1},
+		{"https://*.example.com/images/*", "https://*.example.com/images/*", 0},
+	}
+	for _, tt := range tests {
+		got, shift := pageRuleWildcard(tt.pattern)
+		if got != tt.want || shift != tt.shift {
+			t.Errorf("pageRuleWildcard(%q) = %q, %d; want %q, %d", tt.pattern, got, shift, tt.want, tt.shift)
+		}
+	}
+}
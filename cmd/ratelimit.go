@@ -0,0 +1,451 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var rateLimitCmd = &cobra.Command{
+	Use:     "ratelimit",
+	Aliases: []string{"rl"},
+	Short:   "Rate limiting rules",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var rateLimitListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List rate limiting rules for a zone",
+	RunE:    rateLimitList,
+}
+
+var rateLimitCreateCmd = &cobra.Command{
+	Use:     "create",
+	Aliases: []string{"c"},
+	Short:   "Create a rate limiting rule",
+	RunE:    rateLimitCreate,
+}
+
+var rateLimitUpdateCmd = &cobra.Command{
+	Use:     "update",
+	Aliases: []string{"u"},
+	Short:   "Update a rate limiting rule",
+	RunE:    rateLimitUpdate,
+}
+
+var rateLimitDeleteCmd = &cobra.Command{
+	Use:     "delete",
+	Aliases: []string{"d"},
+	Short:   "Delete a rate limiting rule",
+	RunE:    rateLimitDelete,
+}
+
+// rateLimitPeriods are the counting periods accepted by the API, in seconds.
+var rateLimitPeriods = []int64{10, 60, 120, 300, 600, 3600}
+
+func init() {
+	rootCmd.AddCommand(rateLimitCmd)
+	rateLimitCmd.AddCommand(rateLimitListCmd)
+	rateLimitCmd.AddCommand(rateLimitCreateCmd)
+	rateLimitCmd.AddCommand(rateLimitUpdateCmd)
+	rateLimitCmd.AddCommand(rateLimitDeleteCmd)
+
+	// List flags
+	rateLimitListCmd.Flags().String("zone", "", "zone name")
+
+	// Create and update flags
+	rateLimitCreateCmd.Flags().String("zone", "", "zone name")
+	addRateLimitRuleFlags(rateLimitCreateCmd)
+	rateLimitUpdateCmd.Flags().String("zone", "", "zone name")
+	addRateLimitRuleFlags(rateLimitUpdateCmd)
+	rateLimitUpdateCmd.Flags().String("id", "", "rule ID")
+
+	// Delete flags
+	rateLimitDeleteCmd.Flags().String("zone", "", "zone name")
+	rateLimitDeleteCmd.Flags().String("id", "", "rule ID")
+}
+
+func addRateLimitRuleFlags(c *cobra.Command) {
+	c.Flags().String("file", "", "JSON file with the rule definition; flags override its fields")
+	c.Flags().String("expression", "", "expression matching the requests to rate limit")
+	c.Flags().String("counting-expression", "", "expression selecting the requests to count (defaults to --expression)")
+	c.Flags().String("description", "", "rule description")
+	c.Flags().String("action", "block", "action once the limit is exceeded: block, challenge, js_challenge, managed_challenge, log")
+	c.Flags().Int64("requests", 0, "number of requests allowed per period")
+	c.Flags().Duration("period", time.Minute, "counting period (10s, 1m, 2m, 5m, 10m or 1h)")
+	c.Flags().Duration("timeout", time.Minute, "how long the action applies once triggered")
+	c.Flags().StringSlice("characteristics", []string{"ip.src"}, "request characteristics to count on; cf.colo.id is always added")
+	c.Flags().Bool("requests-to-origin", false, "only count requests that reach the origin")
+	c.Flags().Bool("enabled", true, "whether the rule is executed")
+}
+
+// rateLimitSettings is the ratelimit object of a rule, used for display.
+type rateLimitSettings struct {
+	Characteristics    []string `json:"characteristics"`
+	Period             int64    `json:"period"`
+	RequestsPerPeriod  int64    `json:"requests_per_period"`
+	MitigationTimeout  int64    `json:"mitigation_timeout"`
+	CountingExpression string   `json:"counting_expression"`
+	RequestsToOrigin   bool     `json:"requests_to_origin"`
+}
+
+// rateLimitRuleFile is the format of the --file rule definition.
+type rateLimitRuleFile struct {
+	Action           string                 `json:"action"`
+	ActionParameters json.RawMessage        `json:"action_parameters"`
+	Description      string                 `json:"description"`
+	Expression       string                 `json:"expression"`
+	Enabled          *bool                  `json:"enabled"`
+	Ratelimit        map[string]interface{} `json:"ratelimit"`
+}
+
+func durationSeconds(flag string, d time.Duration) (int64, error) {
+	if d < 0 || d%time.Second != 0 {
+		return 0, fmt.Errorf("--%s must be a whole number of seconds, got %s", flag, d)
+	}
+	return int64(d / time.Second), nil
+}
+
+func checkRateLimitPeriod(period int64) error {
+	for _, p := range rateLimitPeriods {
+		if p == period {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported rate limiting period %ds", period)
+}
+
+// applyRateLimitFlags sets the ratelimit fields given on the command line in
+// settings. If all is true, flags that were not given fill in fields missing
+// from settings with their defaults.
+func applyRateLimitFlags(c *cobra.Command, settings map[string]interface{}, all bool) error {
+	set := func(flag, key string) bool {
+		if c.Flags().Changed(flag) {
+			return true
+		}
+		_, ok := settings[key]
+		return all && !ok
+	}
+
+	if set("requests", "requests_per_period") {
+		requests, _ := c.Flags().GetInt64("requests")
+		if requests < 1 {
+			return errors.New("--requests must be 1 or greater")
+		}
+		settings["requests_per_period"] = requests
+	}
+	if set("period", "period") {
+		d, _ := c.Flags().GetDuration("period")
+		period, err := durationSeconds("period", d)
+		if err != nil {
+			return err
+		}
+		if err := checkRateLimitPeriod(period); err != nil {
+			return err
+		}
+		settings["period"] = period
+	}
+	if set("timeout", "mitigation_timeout") {
+		d, _ := c.Flags().GetDuration("timeout")
+		timeout, err := durationSeconds("timeout", d)
+		if err != nil {
+			return err
+		}
+		settings["mitigation_timeout"] = timeout
+	}
+	if set("characteristics", "characteristics") {
+		characteristics, _ := c.Flags().GetStringSlice("characteristics")
+		settings["characteristics"] = characteristics
+	}
+	if c.Flags().Changed("counting-expression") {
+		countingExpression, _ := c.Flags().GetString("counting-expression")
+		if countingExpression == "" {
+			delete(settings, "counting_expression")
+		} else {
+			if err := validateExpression(countingExpression); err != nil {
+				return fmt.Errorf("invalid counting expression: %w", err)
+			}
+			settings["counting_expression"] = countingExpression
+		}
+	}
+	if c.Flags().Changed("requests-to-origin") {
+		requestsToOrigin, _ := c.Flags().GetBool("requests-to-origin")
+		settings["requests_to_origin"] = requestsToOrigin
+	}
+
+	// The API requires counting per data center.
+	var characteristics []string
+	switch v := settings["characteristics"].(type) {
+	case []string:
+		characteristics = v
+	case []interface{}:
+		for _, ch := range v {
+			if s, ok := ch.(string); ok {
+				characteristics = append(characteristics, s)
+			}
+		}
+	}
+	for _, ch := range characteristics {
+		if ch == "cf.colo.id" {
+			return nil
+		}
+	}
+	settings["characteristics"] = append(characteristics, "cf.colo.id")
+	return nil
+}
+
+func readRateLimitRuleFile(path string) (rateLimitRuleFile, error) {
+	var rule rateLimitRuleFile
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return rule, err
+	}
+	if err := json.Unmarshal(b, &rule); err != nil {
+		return rule, fmt.Errorf("invalid rule file %s: %w", path, err)
+	}
+	return rule, nil
+}
+
+// applyRateLimitRuleFlags overrides the rule fields given on the command line.
+func applyRateLimitRuleFlags(c *cobra.Command, rule *rulesetRule) error {
+	if c.Flags().Changed("expression") {
+		rule.Expression, _ = c.Flags().GetString("expression")
+	}
+	if c.Flags().Changed("description") {
+		rule.Description, _ = c.Flags().GetString("description")
+	}
+	if c.Flags().Changed("action") {
+		rule.Action, _ = c.Flags().GetString("action")
+	}
+	if c.Flags().Changed("enabled") {
+		rule.Enabled, _ = c.Flags().GetBool("enabled")
+	}
+	if err := validateExpression(rule.Expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	return checkRuleAction(rule.Action)
+}
+
+func formatRateLimitRule(r rulesets.RulesetGetResponseRule) []string {
+	var settings rateLimitSettings
+	if !r.JSON.Ratelimit.IsNull() {
+		_ = json.Unmarshal([]byte(r.JSON.Ratelimit.Raw()), &settings)
+	}
+	return []string{
+		r.ID,
+		r.Description,
+		string(r.Action),
+		strconv.FormatInt(settings.RequestsPerPeriod, 10),
+		(time.Duration(settings.Period) * time.Second).String(),
+		(time.Duration(settings.MitigationTimeout) * time.Second).String(),
+		strings.Join(settings.Characteristics, ","),
+		formatBool(r.Enabled),
+		r.Expression,
+	}
+}
+
+func writeRateLimitRules(c *cobra.Command, rs *rulesets.RulesetGetResponse) error {
+	if jsonOutput(c) {
+		if rs == nil {
+			return writeJSON([]interface{}{})
+		}
+		return writeJSON(json.RawMessage(rs.JSON.Rules.Raw()))
+	}
+
+	output := make([][]string, 0)
+	if rs != nil {
+		for _, r := range rs.Rules {
+			output = append(output, formatRateLimitRule(r))
+		}
+	}
+	writeTable(output, "ID", "Description", "Action", "Requests", "Period", "Timeout", "Characteristics", "Enabled", "Expression")
+	return nil
+}
+
+func rateLimitList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRatelimit)
+	if err != nil {
+		return fmt.Errorf("Error listing rate limiting rules: %w", err)
+	}
+	return writeRateLimitRules(c, rs)
+}
+
+func rateLimitCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+
+	action, _ := c.Flags().GetString("action")
+	enabled, _ := c.Flags().GetBool("enabled")
+	rule := rulesetRule{
+		Action:  action,
+		Enabled: enabled,
+	}
+	settings := map[string]interface{}{}
+
+	if file, _ := c.Flags().GetString("file"); file != "" {
+		def, err := readRateLimitRuleFile(file)
+		if err != nil {
+			return err
+		}
+		if def.Action != "" {
+			rule.Action = def.Action
+		}
+		if len(def.ActionParameters) > 0 {
+			rule.ActionParameters = def.ActionParameters
+		}
+		if def.Enabled != nil {
+			rule.Enabled = *def.Enabled
+		}
+		rule.Description = def.Description
+		rule.Expression = def.Expression
+		if def.Ratelimit != nil {
+			settings = def.Ratelimit
+		}
+	} else if err := checkFlags(c, "expression"); err != nil {
+		return err
+	}
+
+	if err := applyRateLimitRuleFlags(c, &rule); err != nil {
+		return err
+	}
+	if err := applyRateLimitFlags(c, settings, true); err != nil {
+		return err
+	}
+	rule.Ratelimit = settings
+
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := addPhaseRule(c, "", zoneID, rulesets.PhaseHTTPRatelimit, rule, nil)
+	if err != nil {
+		return fmt.Errorf("Error creating rate limiting rule: %w", err)
+	}
+	return writeRateLimitRules(c, rs)
+}
+
+func rateLimitUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRatelimit)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no rate limiting rules", zoneName)
+	}
+	existing, ok := findRulesetRule(rs, id)
+	if !ok {
+		return fmt.Errorf("rate limiting rule %q not found", id)
+	}
+
+	rule := rulesetRuleFromExisting(existing)
+	settings := map[string]interface{}{}
+	if !existing.JSON.Ratelimit.IsNull() {
+		if err := json.Unmarshal([]byte(existing.JSON.Ratelimit.Raw()), &settings); err != nil {
+			return err
+		}
+	}
+
+	if file, _ := c.Flags().GetString("file"); file != "" {
+		def, err := readRateLimitRuleFile(file)
+		if err != nil {
+			return err
+		}
+		if def.Action != "" {
+			rule.Action = def.Action
+			rule.ActionParameters = nil
+		}
+		if len(def.ActionParameters) > 0 {
+			rule.ActionParameters = def.ActionParameters
+		}
+		if def.Enabled != nil {
+			rule.Enabled = *def.Enabled
+		}
+		if def.Description != "" {
+			rule.Description = def.Description
+		}
+		if def.Expression != "" {
+			rule.Expression = def.Expression
+		}
+		for k, v := range def.Ratelimit {
+			settings[k] = v
+		}
+	}
+	if c.Flags().Changed("action") {
+		// Parameters of the previous action do not apply to the new one.
+		rule.ActionParameters = nil
+	}
+
+	if err := applyRateLimitRuleFlags(c, &rule); err != nil {
+		return err
+	}
+	if err := applyRateLimitFlags(c, settings, false); err != nil {
+		return err
+	}
+	rule.Ratelimit = settings
+
+	rs, err = editRulesetRule(c, "", zoneID, rs.ID, id, rule, nil)
+	if err != nil {
+		return fmt.Errorf("Error updating rate limiting rule: %w", err)
+	}
+	return writeRateLimitRules(c, rs)
+}
+
+func rateLimitDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRatelimit)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no rate limiting rules", zoneName)
+	}
+
+	rs, err = deleteRulesetRule(c, "", zoneID, rs.ID, id)
+	if err != nil {
+		return fmt.Errorf("Error deleting rate limiting rule: %w", err)
+	}
+	return writeRateLimitRules(c, rs)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newRateLimitTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	c := &cobra.Command{Use: "test"}
+	addRateLimitRuleFlags(c)
+	if err := c.Flags().Parse(args); err != nil {
+		t.Fatalf("Parse(%v) failed: %v", args, err)
+	}
+	return c
+}
+
+func TestApplyRateLimitFlagsCreate(t *testing.T) {
+	c := newRateLimitTestCmd(t, "--requests", "100", "--period", "60s", "--timeout", "10m", "--characteristics", "ip.src")
+	settings := map[string]interface{}{}
+	if err := applyRateLimitFlags(c, settings, true); err != nil {
+		t.Fatalf("applyRateLimitFlags failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"requests_per_period": int64(100),
+		"period":              int64(60),
+		"mitigation_timeout":  int64(600),
+		"characteristics":     []string{"ip.src", "cf.colo.id"},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("settings = %v; want %v", settings, want)
+	}
+}
+
+func TestApplyRateLimitFlagsUpdate(t *testing.T) {
+	c := newRateLimitTestCmd(t, "--period", "10m")
+	settings := map[string]interface{}{
+		"requests_per_period": float64(5),
+		"period":              float64(60),
+		"characteristics":     []interface{}{"ip.src", "cf.colo.id"},
+	}
+	if err := applyRateLimitFlags(c, settings, false); err != nil {
+		t.Fatalf("applyRateLimitFlags failed: %v", err)
+	}
+	if settings["period"] != int64(600) {
+		t.Errorf("period = %v; want 600", settings["period"])
+	}
+	if settings["requests_per_period"] != float64(5) {
+		t.Errorf("requests_per_period = %v; want it unchanged", settings["requests_per_period"])
+	}
+	if _, ok := settings["mitigation_timeout"]; ok {
+		t.Error("mitigation_timeout should not be set when --timeout is not given")
+	}
+}
+
+func TestApplyRateLimitFlagsErrors(t *testing.T) {
+	tests := [][]string{
+		{"--period", "60s"},
+		{"--requests", "10", "--period", "45s"},
+		{"--requests", "10", "--timeout", "1500ms"},
+	}
+	for _, args := range tests {
+		c := newRateLimitTestCmd(t, args...)
+		if err := applyRateLimitFlags(c, map[string]interface{}{}, true); err == nil {
+			t.Errorf("applyRateLimitFlags(%v) = nil; want error", args)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var redirectsCmd = &cobra.Command{
+	Use:   "redirects",
+	Short: "Single redirect rules and bulk redirect lists",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var redirectsRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Single redirect rules of a zone (http_request_dynamic_redirect phase)",
+}
+
+var redirectsRulesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the single redirect rules of a zone",
+	RunE:    redirectsRulesList,
+}
+
+var redirectsRulesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a single redirect rule",
+	RunE:  redirectsRulesCreate,
+}
+
+var redirectsRulesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a single redirect rule",
+	RunE:  redirectsRulesUpdate,
+}
+
+var redirectsRulesDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a single redirect rule",
+	RunE:  redirectsRulesDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(redirectsCmd)
+	redirectsCmd.AddCommand(redirectsRulesCmd)
+	redirectsRulesCmd.AddCommand(redirectsRulesListCmd)
+	redirectsRulesCmd.AddCommand(redirectsRulesCreateCmd)
+	redirectsRulesCmd.AddCommand(redirectsRulesUpdateCmd)
+	redirectsRulesCmd.AddCommand(redirectsRulesDeleteCmd)
+
+	redirectsRulesListCmd.Flags().String("zone", "", "zone name")
+
+	addRedirectRuleFlags(redirectsRulesCreateCmd)
+	addRulePositionFlags(redirectsRulesCreateCmd)
+
+	addRedirectRuleFlags(redirectsRulesUpdateCmd)
+	addRulePositionFlags(redirectsRulesUpdateCmd)
+	redirectsRulesUpdateCmd.Flags().String("id", "", "rule ID")
+
+	redirectsRulesDeleteCmd.Flags().String("zone", "", "zone name")
+	redirectsRulesDeleteCmd.Flags().String("id", "", "rule ID")
+}
+
+func addRedirectRuleFlags(c *cobra.Command) {
+	c.Flags().String("zone", "", "zone name")
+	c.Flags().String("expression", "", "filter expression of the requests to redirect")
+	c.Flags().String("description", "", "rule description")
+	c.Flags().Bool("enabled", true, "whether the rule is enabled")
+	c.Flags().String("target", "", "URL to redirect to")
+	c.Flags().String("target-expression", "", "expression building the URL to redirect to")
+	c.Flags().Int("status-code", 301, "redirect status code: 301, 302, 307 or 308")
+	c.Flags().Bool("preserve-query-string", false, "keep the query string of the request")
+}
+
+// checkRedirectStatus checks that code is a status code allowed for redirects.
+func checkRedirectStatus(code int) error {
+	switch code {
+	case 301, 302, 307, 308:
+		return nil
+	}
+	return fmt.Errorf("invalid status code %d: must be 301, 302, 307 or 308", code)
+}
+
+// validateRedirectURL checks that s is an absolute http or https URL.
+func validateRedirectURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", s)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no hostname", s)
+	}
+	return nil
+}
+
+// applyRedirectRuleFlags merges the flags given on the command line into the
+// from_value parameters of a redirect action.
+func applyRedirectRuleFlags(c *cobra.Command, fromValue map[string]interface{}) error {
+	target, _ := c.Flags().GetString("target")
+	targetExpression, _ := c.Flags().GetString("target-expression")
+	if target != "" && targetExpression != "" {
+		return errors.New("only one of --target and --target-expression can be given")
+	}
+	if target != "" {
+		if err := validateRedirectURL(target); err != nil {
+			return fmt.Errorf("invalid --target: %w", err)
+		}
+		fromValue["target_url"] = map[string]interface{}{"value": target}
+	}
+	if targetExpression != "" {
+		fromValue["target_url"] = map[string]interface{}{"expression": targetExpression}
+	}
+
+	if _, ok := fromValue["status_code"]; !ok || c.Flags().Changed("status-code") {
+		code, _ := c.Flags().GetInt("status-code")
+		if err := checkRedirectStatus(code); err != nil {
+			return err
+		}
+		fromValue["status_code"] = code
+	}
+	if c.Flags().Changed("preserve-query-string") {
+		fromValue["preserve_query_string"], _ = c.Flags().GetBool("preserve-query-string")
+	}
+	return nil
+}
+
+func formatRedirectRule(r rulesets.RulesetGetResponseRule) []string {
+	var params struct {
+		FromValue struct {
+			StatusCode          int  `json:"status_code"`
+			PreserveQueryString bool `json:"preserve_query_string"`
+			TargetURL           struct {
+				Value      string `json:"value"`
+				Expression string `json:"expression"`
+			} `json:"target_url"`
+		} `json:"from_value"`
+	}
+	if !r.JSON.ActionParameters.IsNull() {
+		_ = json.Unmarshal([]byte(r.JSON.ActionParameters.Raw()), &params)
+	}
+	from := params.FromValue
+	target := from.TargetURL.Value
+	if target == "" {
+		target = from.TargetURL.Expression
+	}
+	status := ""
+	if from.StatusCode != 0 {
+		status = strconv.Itoa(from.StatusCode)
+	}
+	return []string{
+		r.ID,
+		r.Description,
+		status,
+		target,
+		formatBool(from.PreserveQueryString),
+		formatBool(r.Enabled),
+		r.Expression,
+	}
+}
+
+func writeRedirectRules(c *cobra.Command, rs *rulesets.RulesetGetResponse) error {
+	if jsonOutput(c) {
+		if rs == nil {
+			return writeJSON([]interface{}{})
+		}
+		return writeJSON(json.RawMessage(rs.JSON.Rules.Raw()))
+	}
+
+	output := make([][]string, 0)
+	if rs != nil {
+		for _, r := range rs.Rules {
+			output = append(output, formatRedirectRule(r))
+		}
+	}
+	writeTable(output, "ID", "Description", "Status", "Target", "Query String", "Enabled", "Expression")
+	return nil
+}
+
+func redirectsRulesList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestDynamicRedirect)
+	if err != nil {
+		return fmt.Errorf("Error listing redirect rules: %w", err)
+	}
+	return writeRedirectRules(c, rs)
+}
+
+func redirectsRulesCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "expression"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	expression, _ := c.Flags().GetString("expression")
+	description, _ := c.Flags().GetString("description")
+	enabled, _ := c.Flags().GetBool("enabled")
+
+	if err := validateExpression(expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	fromValue := map[string]interface{}{}
+	if err := applyRedirectRuleFlags(c, fromValue); err != nil {
+		return err
+	}
+	if _, ok := fromValue["target_url"]; !ok {
+		return errors.New("one of --target or --target-expression is required")
+	}
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rule := rulesetRule{
+		Action:           string(rulesets.RulesetGetResponseRulesActionRedirect),
+		ActionParameters: map[string]interface{}{"from_value": fromValue},
+		Description:      description,
+		Expression:       expression,
+		Enabled:          enabled,
+	}
+	rs, err := addPhaseRule(c, "", zoneID, rulesets.PhaseHTTPRequestDynamicRedirect, rule, position)
+	if err != nil {
+		return fmt.Errorf("Error creating redirect rule: %w", err)
+	}
+	return writeRedirectRules(c, rs)
+}
+
+func redirectsRulesUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestDynamicRedirect)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no redirect rules", zoneName)
+	}
+	existing, ok := findRulesetRule(rs, id)
+	if !ok {
+		return fmt.Errorf("redirect rule %q not found", id)
+	}
+
+	rule := rulesetRuleFromExisting(existing)
+	params := map[string]interface{}{}
+	if !existing.JSON.ActionParameters.IsNull() {
+		if err := json.Unmarshal([]byte(existing.JSON.ActionParameters.Raw()), &params); err != nil {
+			return err
+		}
+	}
+	fromValue, _ := params["from_value"].(map[string]interface{})
+	if fromValue == nil {
+		fromValue = map[string]interface{}{}
+	}
+	if err := applyRedirectRuleFlags(c, fromValue); err != nil {
+		return err
+	}
+	params["from_value"] = fromValue
+	rule.ActionParameters = params
+
+	if c.Flags().Changed("expression") {
+		rule.Expression, _ = c.Flags().GetString("expression")
+		if err := validateExpression(rule.Expression); err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+	}
+	if c.Flags().Changed("description") {
+		rule.Description, _ = c.Flags().GetString("description")
+	}
+	if c.Flags().Changed("enabled") {
+		rule.Enabled, _ = c.Flags().GetBool("enabled")
+	}
+
+	rs, err = editRulesetRule(c, "", zoneID, rs.ID, id, rule, position)
+	if err != nil {
+		return fmt.Errorf("Error updating redirect rule: %w", err)
+	}
+	return writeRedirectRules(c, rs)
+}
+
+func redirectsRulesDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestDynamicRedirect)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no redirect rules", zoneName)
+	}
+
+	rs, err = deleteRulesetRule(c, "", zoneID, rs.ID, id)
+	if err != nil {
+		return fmt.Errorf("Error deleting redirect rule: %w", err)
+	}
+	return writeRedirectRules(c, rs)
+}
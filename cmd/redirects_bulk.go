@@ -0,0 +1,680 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/rules"
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var redirectsListsCmd = &cobra.Command{
+	Use:   "lists",
+	Short: "Bulk redirect lists of an account",
+}
+
+var redirectsListsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the bulk redirect lists of an account",
+	RunE:    redirectsListsList,
+}
+
+var redirectsListsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a bulk redirect list",
+	RunE:  redirectsListsCreate,
+}
+
+var redirectsListsItemsCmd = &cobra.Command{
+	Use:   "items",
+	Short: "Show the redirects of a bulk redirect list",
+	RunE:  redirectsListsItems,
+}
+
+var redirectsListsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import redirects from a CSV file into a bulk redirect list",
+	Long: `Import redirects from a CSV file into a bulk redirect list.
+
+Each line holds source URL, target URL, status code, preserve query string,
+include subdomains, subpath matching and preserve path suffix; only the first
+two columns are required. A header line naming the columns may be used to
+give them in another order. The file is checked locally and compared with the
+items already in the list before anything is sent.`,
+	RunE: redirectsListsImport,
+}
+
+var redirectsListsEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable a bulk redirect list with a rule in the http_request_redirect phase",
+	RunE:  redirectsListsEnable,
+}
+
+// bulkRedirectPollInterval is how often the status of a bulk operation on a
+// list is checked.
+var bulkRedirectPollInterval = time.Second
+
+func init() {
+	redirectsCmd.AddCommand(redirectsListsCmd)
+	redirectsListsCmd.AddCommand(redirectsListsListCmd)
+	redirectsListsCmd.AddCommand(redirectsListsCreateCmd)
+	redirectsListsCmd.AddCommand(redirectsListsItemsCmd)
+	redirectsListsCmd.AddCommand(redirectsListsImportCmd)
+	redirectsListsCmd.AddCommand(redirectsListsEnableCmd)
+
+	redirectsListsListCmd.Flags().String("account", "", "account name or ID")
+
+	redirectsListsCreateCmd.Flags().String("account", "", "account name or ID")
+	redirectsListsCreateCmd.Flags().String("name", "", "list name")
+	redirectsListsCreateCmd.Flags().String("description", "", "list description")
+
+	redirectsListsItemsCmd.Flags().String("account", "", "account name or ID")
+	redirectsListsItemsCmd.Flags().String("list", "", "list name or ID")
+
+	redirectsListsImportCmd.Flags().String("account", "", "account name or ID")
+	redirectsListsImportCmd.Flags().String("list", "", "list name or ID")
+	redirectsListsImportCmd.Flags().StringP("file", "f", "", "CSV file to import, - for stdin")
+	redirectsListsImportCmd.Flags().Bool("prune", false, "remove list items that are not in the file")
+	redirectsListsImportCmd.Flags().Bool("dry-run", false, "only show the changes")
+
+	redirectsListsEnableCmd.Flags().String("account", "", "account name or ID")
+	redirectsListsEnableCmd.Flags().String("list", "", "list name or ID")
+	redirectsListsEnableCmd.Flags().String("description", "", "rule description")
+}
+
+// bulkRedirect is one redirect of a bulk redirect list.
+type bulkRedirect struct {
+	SourceURL           string `json:"source_url"`
+	TargetURL           string `json:"target_url"`
+	StatusCode          int    `json:"status_code"`
+	PreserveQueryString bool   `json:"preserve_query_string"`
+	IncludeSubdomains   bool   `json:"include_subdomains"`
+	SubpathMatching     bool   `json:"subpath_matching"`
+	PreservePathSuffix  bool   `json:"preserve_path_suffix"`
+}
+
+func (r bulkRedirect) param() rules.RedirectParam {
+	return rules.RedirectParam{
+		SourceURL:           cloudflare.F(r.SourceURL),
+		TargetURL:           cloudflare.F(r.TargetURL),
+		StatusCode:          cloudflare.F(rules.RedirectStatusCode(r.StatusCode)),
+		PreserveQueryString: cloudflare.F(r.PreserveQueryString),
+		IncludeSubdomains:   cloudflare.F(r.IncludeSubdomains),
+		SubpathMatching:     cloudflare.F(r.SubpathMatching),
+		PreservePathSuffix:  cloudflare.F(r.PreservePathSuffix),
+	}
+}
+
+// bulkRedirectColumns are the CSV columns in their default order, with the
+// names accepted for them in a header line.
+var bulkRedirectColumns = []struct {
+	names []string
+	set   func(r *bulkRedirect, v string) error
+}{
+	{[]string{"source", "source_url"}, func(r *bulkRedirect, v string) error { r.SourceURL = v; return nil }},
+	{[]string{"target", "target_url"}, func(r *bulkRedirect, v string) error { r.TargetURL = v; return nil }},
+	{[]string{"status", "status_code"}, func(r *bulkRedirect, v string) (err error) {
+		if v != "" {
+			r.StatusCode, err = strconv.Atoi(v)
+		}
+		return err
+	}},
+	{[]string{"preserve_query_string"}, func(r *bulkRedirect, v string) error { return parseCSVBool(v, &r.PreserveQueryString) }},
+	{[]string{"include_subdomains"}, func(r *bulkRedirect, v string) error { return parseCSVBool(v, &r.IncludeSubdomains) }},
+	{[]string{"subpath_matching"}, func(r *bulkRedirect, v string) error { return parseCSVBool(v, &r.SubpathMatching) }},
+	{[]string{"preserve_path_suffix"}, func(r *bulkRedirect, v string) error { return parseCSVBool(v, &r.PreservePathSuffix) }},
+}
+
+func parseCSVBool(v string, b *bool) error {
+	if v == "" {
+		return nil
+	}
+	var err error
+	*b, err = strconv.ParseBool(v)
+	return err
+}
+
+// parseBulkRedirectsCSV reads redirects from CSV. Lines starting with # are
+// ignored. Every redirect is validated, and a source URL may only appear once.
+func parseBulkRedirectsCSV(r io.Reader) ([]bulkRedirect, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	order := make([]int, len(bulkRedirectColumns))
+	for i := range order {
+		order[i] = i
+	}
+
+	var redirects []bulkRedirect
+	seen := map[string]int{}
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first && bulkRedirectColumn(record[0]) >= 0 {
+			order = order[:0]
+			for _, name := range record {
+				col := bulkRedirectColumn(name)
+				if col < 0 {
+					return nil, fmt.Errorf("line %d: unknown column %q", line, name)
+				}
+				order = append(order, col)
+			}
+			continue
+		}
+
+		if len(record) > len(order) {
+			return nil, fmt.Errorf("line %d: too many fields", line)
+		}
+		var rd bulkRedirect
+		for i, v := range record {
+			col := bulkRedirectColumns[order[i]]
+			if err := col.set(&rd, strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q", line, col.names[0], v)
+			}
+		}
+		if rd.StatusCode == 0 {
+			rd.StatusCode = 301
+		}
+		if err := validateBulkRedirect(rd); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, ok := seen[rd.SourceURL]; ok {
+			return nil, fmt.Errorf("line %d: source URL %s already used on line %d", line, rd.SourceURL, prev)
+		}
+		seen[rd.SourceURL] = line
+		redirects = append(redirects, rd)
+	}
+	return redirects, nil
+}
+
+// bulkRedirectColumn returns the index in bulkRedirectColumns of the column
+// named in a header line, or -1.
+func bulkRedirectColumn(name string) int {
+	name = strings.TrimSpace(name)
+	for i, c := range bulkRedirectColumns {
+		for _, n := range c.names {
+			if strings.EqualFold(n, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// validateBulkRedirect checks a redirect the way the API does: the source URL
+// has a hostname, an optional http or https scheme and no query string or
+// fragment, and the target is an absolute URL.
+func validateBulkRedirect(r bulkRedirect) error {
+	if r.SourceURL == "" || r.TargetURL == "" {
+		return errors.New("source and target URL are required")
+	}
+	source := r.SourceURL
+	if !strings.Contains(source, "://") {
+		source = "https://" + source
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		return fmt.Errorf("invalid source URL %q: %w", r.SourceURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid source URL %q: scheme must be http or https", r.SourceURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid source URL %q: no hostname", r.SourceURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" || strings.ContainsAny(r.SourceURL, "?#") {
+		return fmt.Errorf("invalid source URL %q: query string and fragment are not allowed", r.SourceURL)
+	}
+	if err := validateRedirectURL(r.TargetURL); err != nil {
+		return fmt.Errorf("invalid target URL: %w", err)
+	}
+	if err := checkRedirectStatus(r.StatusCode); err != nil {
+		return err
+	}
+	if r.PreservePathSuffix && !r.SubpathMatching {
+		return fmt.Errorf("preserve_path_suffix on %s requires subpath_matching", r.SourceURL)
+	}
+	return nil
+}
+
+// bulkRedirectItem is a redirect already in a list.
+type bulkRedirectItem struct {
+	ID string `json:"id"`
+	bulkRedirect
+}
+
+// bulkRedirectChange is a difference between a list and an import file.
+type bulkRedirectChange struct {
+	Action   string        `json:"action"`
+	Redirect bulkRedirect  `json:"redirect"`
+	Previous *bulkRedirect `json:"previous,omitempty"`
+}
+
+// diffBulkRedirects compares the items of a list with the redirects to import,
+// matching them by source URL. Items missing from the import are only
+// reported as removed if prune is set. It also returns the full list of
+// redirects the list should hold afterwards.
+func diffBulkRedirects(existing []bulkRedirectItem, desired []bulkRedirect, prune bool) (changes []bulkRedirectChange, final []bulkRedirect) {
+	current := make(map[string]bulkRedirect, len(existing))
+	for _, item := range existing {
+		current[item.SourceURL] = item.bulkRedirect
+	}
+	wanted := make(map[string]bool, len(desired))
+
+	for _, r := range desired {
+		wanted[r.SourceURL] = true
+		prev, ok := current[r.SourceURL]
+		switch {
+		case !ok:
+			changes = append(changes, bulkRedirectChange{Action: "add", Redirect: r})
+		case prev != r:
+			changes = append(changes, bulkRedirectChange{Action: "change", Redirect: r, Previous: &prev})
+		}
+		final = append(final, r)
+	}
+	for _, item := range existing {
+		if wanted[item.SourceURL] {
+			continue
+		}
+		if prune {
+			changes = append(changes, bulkRedirectChange{Action: "remove", Redirect: item.bulkRedirect})
+			continue
+		}
+		final = append(final, item.bulkRedirect)
+	}
+	return changes, final
+}
+
+// findRedirectList finds a redirect list of the account by name or ID.
+func findRedirectList(c *cobra.Command, accountID, name string) (rules.ListsList, error) {
+	iter := client.Rules.Lists.ListAutoPaging(c.Context(), rules.ListListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		l := iter.Current()
+		if l.ID == name || l.Name == name {
+			if l.Kind != rules.ListsListKindRedirect {
+				return l, fmt.Errorf("list %q is not a redirect list", name)
+			}
+			return l, nil
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return rules.ListsList{}, fmt.Errorf("Error listing lists: %w", err)
+	}
+	return rules.ListsList{}, fmt.Errorf("redirect list %q not found", name)
+}
+
+func getBulkRedirectItems(c *cobra.Command, accountID, listID string) ([]bulkRedirectItem, error) {
+	var items []bulkRedirectItem
+	iter := client.Rules.Lists.Items.ListAutoPaging(c.Context(), listID, rules.ListItemListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		item := iter.Current()
+		rd := item.Redirect
+		status := int(rd.StatusCode)
+		if status == 0 {
+			status = 301
+		}
+		items = append(items, bulkRedirectItem{
+			ID: item.ID,
+			bulkRedirect: bulkRedirect{
+				SourceURL:           rd.SourceURL,
+				TargetURL:           rd.TargetURL,
+				StatusCode:          status,
+				PreserveQueryString: rd.PreserveQueryString,
+				IncludeSubdomains:   rd.IncludeSubdomains,
+				SubpathMatching:     rd.SubpathMatching,
+				PreservePathSuffix:  rd.PreservePathSuffix,
+			},
+		})
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("Error listing list items: %w", err)
+	}
+	return items, nil
+}
+
+// waitBulkOperation waits for an asynchronous list operation to finish.
+func waitBulkOperation(c *cobra.Command, accountID, operationID string) error {
+	for {
+		op, err := client.Rules.Lists.BulkOperations.Get(c.Context(), operationID, rules.ListBulkOperationGetParams{
+			AccountID: cloudflare.F(accountID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting bulk operation status: %w", err)
+		}
+		switch op.Status {
+		case rules.ListBulkOperationGetResponseStatusCompleted:
+			return nil
+		case rules.ListBulkOperationGetResponseStatusFailed:
+			return fmt.Errorf("bulk operation %s failed: %s", operationID, op.Error)
+		}
+		select {
+		case <-c.Context().Done():
+			return c.Context().Err()
+		case <-time.After(bulkRedirectPollInterval):
+		}
+	}
+}
+
+func formatBulkRedirect(r bulkRedirect) []string {
+	var options []string
+	for _, o := range []struct {
+		name string
+		on   bool
+	}{
+		{"preserve_query_string", r.PreserveQueryString},
+		{"include_subdomains", r.IncludeSubdomains},
+		{"subpath_matching", r.SubpathMatching},
+		{"preserve_path_suffix", r.PreservePathSuffix},
+	} {
+		if o.on {
+			options = append(options, o.name)
+		}
+	}
+	return []string{r.SourceURL, r.TargetURL, strconv.Itoa(r.StatusCode), strings.Join(options, ",")}
+}
+
+func redirectsListsList(c *cobra.Command, args []string) error {
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	var lists []rules.ListsList
+	iter := client.Rules.Lists.ListAutoPaging(c.Context(), rules.ListListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		if l := iter.Current(); l.Kind == rules.ListsListKindRedirect {
+			lists = append(lists, l)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing lists: %w", err)
+	}
+
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(lists))
+		for _, l := range lists {
+			raw = append(raw, json.RawMessage(l.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(lists))
+	for _, l := range lists {
+		output = append(output, []string{
+			l.ID,
+			l.Name,
+			strconv.Itoa(int(l.NumItems)),
+			strconv.Itoa(int(l.NumReferencingFilters)),
+			l.Description,
+		})
+	}
+	writeTable(output, "ID", "Name", "Items", "Rules", "Description")
+	return nil
+}
+
+func redirectsListsCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "name"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("name")
+	description, _ := c.Flags().GetString("description")
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	params := rules.ListNewParams{
+		AccountID: cloudflare.F(accountID),
+		Kind:      cloudflare.F(rules.ListNewParamsKindRedirect),
+		Name:      cloudflare.F(name),
+	}
+	if description != "" {
+		params.Description = cloudflare.F(description)
+	}
+	l, err := client.Rules.Lists.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error creating redirect list: %w", err)
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(l.JSON.RawJSON()))
+	}
+	writeTable([][]string{{l.ID, l.Name, l.Description}}, "ID", "Name", "Description")
+	return nil
+}
+
+func redirectsListsItems(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "list"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("list")
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+	l, err := findRedirectList(c, accountID, name)
+	if err != nil {
+		return err
+	}
+	items, err := getBulkRedirectItems(c, accountID, l.ID)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput(c) {
+		if items == nil {
+			items = []bulkRedirectItem{}
+		}
+		return writeJSON(items)
+	}
+
+	output := make([][]string, 0, len(items))
+	for _, item := range items {
+		output = append(output, append([]string{item.ID}, formatBulkRedirect(item.bulkRedirect)...))
+	}
+	writeTable(output, "ID", "Source", "Target", "Status", "Options")
+	return nil
+}
+
+func redirectsListsImport(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "list", "file"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("list")
+	file, _ := c.Flags().GetString("file")
+	prune, _ := c.Flags().GetBool("prune")
+	dryRun, _ := c.Flags().GetBool("dry-run")
+
+	f := os.Stdin
+	if file != "-" {
+		var err error
+		if f, err = os.Open(file); err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+	desired, err := parseBulkRedirectsCSV(f)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", file, err)
+	}
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+	l, err := findRedirectList(c, accountID, name)
+	if err != nil {
+		return err
+	}
+	existing, err := getBulkRedirectItems(c, accountID, l.ID)
+	if err != nil {
+		return err
+	}
+
+	changes, final := diffBulkRedirects(existing, desired, prune)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Redirect.SourceURL < changes[j].Redirect.SourceURL
+	})
+
+	if jsonOutput(c) {
+		if changes == nil {
+			changes = []bulkRedirectChange{}
+		}
+		if err := writeJSON(changes); err != nil {
+			return err
+		}
+	} else {
+		output := make([][]string, 0, len(changes))
+		for _, ch := range changes {
+			output = append(output, append([]string{ch.Action}, formatBulkRedirect(ch.Redirect)...))
+		}
+		writeTable(output, "Action", "Source", "Target", "Status", "Options")
+	}
+
+	if dryRun || len(changes) == 0 {
+		return nil
+	}
+
+	// Additions alone can be appended; anything else replaces the whole list.
+	var operationID string
+	onlyAdds := true
+	for _, ch := range changes {
+		if ch.Action != "add" {
+			onlyAdds = false
+		}
+	}
+	if onlyAdds {
+		body := make([]rules.ListItemNewParamsBodyUnion, 0, len(changes))
+		for _, ch := range changes {
+			body = append(body, rules.ListItemNewParamsBodyListsListItemRedirectComment{Redirect: cloudflare.F(ch.Redirect.param())})
+		}
+		res, err := client.Rules.Lists.Items.New(c.Context(), l.ID, rules.ListItemNewParams{
+			AccountID: cloudflare.F(accountID),
+			Body:      body,
+		})
+		if err != nil {
+			return fmt.Errorf("Error adding list items: %w", err)
+		}
+		operationID = res.OperationID
+	} else {
+		body := make([]rules.ListItemUpdateParamsBodyUnion, 0, len(final))
+		for _, r := range final {
+			body = append(body, rules.ListItemUpdateParamsBodyListsListItemRedirectComment{Redirect: cloudflare.F(r.param())})
+		}
+		res, err := client.Rules.Lists.Items.Update(c.Context(), l.ID, rules.ListItemUpdateParams{
+			AccountID: cloudflare.F(accountID),
+			Body:      body,
+		})
+		if err != nil {
+			return fmt.Errorf("Error replacing list items: %w", err)
+		}
+		operationID = res.OperationID
+	}
+	return waitBulkOperation(c, accountID, operationID)
+}
+
+// findListRedirectRule finds the rule of a redirect phase ruleset that uses
+// the list.
+func findListRedirectRule(rs *rulesets.RulesetGetResponse, listName string) (rulesets.RulesetGetResponseRule, bool) {
+	for _, r := range rs.Rules {
+		var params struct {
+			FromList struct {
+				Name string `json:"name"`
+			} `json:"from_list"`
+		}
+		if r.JSON.ActionParameters.IsNull() {
+			continue
+		}
+		if err := json.Unmarshal([]byte(r.JSON.ActionParameters.Raw()), &params); err == nil && params.FromList.Name == listName {
+			return r, true
+		}
+	}
+	return rulesets.RulesetGetResponseRule{}, false
+}
+
+func redirectsListsEnable(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "list"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("list")
+	description, _ := c.Flags().GetString("description")
+
+	accountID, err := getAccountID(c)
+	if err != nil {
+		return err
+	}
+	l, err := findRedirectList(c, accountID, name)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, accountID, "", rulesets.PhaseHTTPRequestRedirect)
+	if err != nil {
+		return err
+	}
+	if rs != nil {
+		if existing, ok := findListRedirectRule(rs, l.Name); ok {
+			if existing.Enabled {
+				fmt.Fprintf(os.Stderr, "List %s is already enabled by rule %s\n", l.Name, existing.ID)
+				return writeRuleset(c, rs)
+			}
+			rule := rulesetRuleFromExisting(existing)
+			rule.Enabled = true
+			rs, err = editRulesetRule(c, accountID, "", rs.ID, existing.ID, rule, nil)
+			if err != nil {
+				return fmt.Errorf("Error enabling redirect list: %w", err)
+			}
+			return writeRuleset(c, rs)
+		}
+	}
+
+	if description == "" {
+		description = "Bulk redirects from list " + l.Name
+	}
+	rule := rulesetRule{
+		Action: string(rulesets.RulesetGetResponseRulesActionRedirect),
+		ActionParameters: map[string]interface{}{
+			"from_list": map[string]interface{}{
+				"name": l.Name,
+				"key":  "http.request.full_uri",
+			},
+		},
+		Description: description,
+		Expression:  "http.request.full_uri in $" + l.Name,
+		Enabled:     true,
+	}
+	rs, err = addPhaseRule(c, accountID, "", rulesets.PhaseHTTPRequestRedirect, rule, nil)
+	if err != nil {
+		return fmt.Errorf("Error enabling redirect list: %w", err)
+	}
+	return writeRuleset(c, rs)
+}
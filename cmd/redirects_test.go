@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseBulkRedirectsCSV(t *testing.T) {
+	input := `# vanity redirects
+example.com/docs,https://docs.example.com/,302,true
+https://example.com/blog, https://blog.example.com/
+`
+	got, err := parseBulkRedirectsCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bulkRedirect{
+		{SourceURL: "example.com/docs", TargetURL: "https://docs.example.com/", StatusCode: 302, PreserveQueryString: true},
+		{SourceURL: "https://example.com/blog", TargetURL: "https://blog.example.com/", StatusCode: 301},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBulkRedirectsCSV() = %+v; want %+v", got, want)
+	}
+
+	header := "Target_URL,source,subpath_matching,preserve_path_suffix\nhttps://new.example.com/,old.example.com/,true,true\n"
+	got, err = parseBulkRedirectsCSV(strings.NewReader(header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []bulkRedirect{{SourceURL: "old.example.com/", TargetURL: "https://new.example.com/", StatusCode: 301, SubpathMatching: true, PreservePathSuffix: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBulkRedirectsCSV() with header = %+v; want %+v", got, want)
+	}
+
+	for _, bad := range []string{
+		"example.com/a,https://example.net/\nexample.com/a,https://example.org/\n",
+		"example.com/a?x=1,https://example.net/\n",
+		"ftp://example.com/a,https://example.net/\n",
+		"example.com/a,/relative\n",
+		"example.com/a,https://example.net/,303\n",
+		"example.com/a,https://example.net/,301,maybe\n",
+		"example.com/a\n",
+	} {
+		if _, err := parseBulkRedirectsCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("parseBulkRedirectsCSV(%q) = nil error; want error", bad)
+		}
+	}
+}
+
+func TestDiffBulkRedirects(t *testing.T) {
+	same := bulkRedirect{SourceURL: "example.com/same", TargetURL: "https://example.net/", StatusCode: 301}
+	old := bulkRedirect{SourceURL: "example.com/changed", TargetURL: "https://example.net/old", StatusCode: 301}
+	changed := bulkRedirect{SourceURL: "example.com/changed", TargetURL: "https://example.net/new", StatusCode: 301}
+	gone := bulkRedirect{SourceURL: "example.com/gone", TargetURL: "https://example.net/", StatusCode: 302}
+	added := bulkRedirect{SourceURL: "example.com/added", TargetURL: "https://example.net/", StatusCode: 301}
+
+	existing := []bulkRedirectItem{{"1", same}, {"2", old}, {"3", gone}}
+	desired := []bulkRedirect{same, changed, added}
+
+	changes, final := diffBulkRedirects(existing, desired, false)
+	wantChanges := []bulkRedirectChange{
+		{Action: "change", Redirect: changed, Previous: &old},
+		{Action: "add", Redirect: added},
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("diffBulkRedirects() changes = %+v; want %+v", changes, wantChanges)
+	}
+	if want := []bulkRedirect{same, changed, added, gone}; !reflect.DeepEqual(final, want) {
+		t.Errorf("diffBulkRedirects() final = %+v; want %+v", final, want)
+	}
+
+	changes, final = diffBulkRedirects(existing, desired, true)
+	if len(changes) != 3 || changes[2].Action != "remove" || changes[2].Redirect != gone {
+		t.Errorf("diffBulkRedirects() with prune changes = %+v", changes)
+	}
+	if want := []bulkRedirect{same, changed, added}; !reflect.DeepEqual(final, want) {
+		t.Errorf("diffBulkRedirects() with prune final = %+v; want %+v", final, want)
+	}
+}
+
+func TestApplyRedirectRuleFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		c := &cobra.Command{Use: "test"}
+		addRedirectRuleFlags(c)
+		if err := c.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	fromValue := map[string]interface{}{}
+	if err := applyRedirectRuleFlags(newCmd("--target", "https://example.com/", "--preserve-query-string"), fromValue); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"target_url":            map[string]interface{}{"value": "https://example.com/"},
+		"status_code":           301,
+		"preserve_query_string": true,
+	}
+	if !reflect.DeepEqual(fromValue, want) {
+		t.Errorf("applyRedirectRuleFlags() = %v; want %v", fromValue, want)
+	}
+
+	// Updates keep the existing status code unless it is given.
+	fromValue = map[string]interface{}{"status_code": float64(308)}
+	if err := applyRedirectRuleFlags(newCmd("--target-expression", `concat("https://example.com", http.request.uri.path)`), fromValue); err != nil {
+		t.Fatal(err)
+	}
+	if fromValue["status_code"] != float64(308) {
+		t.Errorf("status_code = %v; want 308", fromValue["status_code"])
+	}
+
+	for _, args := range [][]string{
+		{"--target", "example.com"},
+		{"--target", "https://example.com/", "--target-expression", "x"},
+		{"--target", "https://example.com/", "--status-code", "200"},
+	} {
+		if err := applyRedirectRuleFlags(newCmd(args...), map[string]interface{}{}); err == nil {
+			t.Errorf("applyRedirectRuleFlags(%v) = nil error; want error", args)
+		}
+	}
+}
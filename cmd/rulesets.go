@@ -0,0 +1,871 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var rulesetsCmd = &cobra.Command{
+	Use:     "rulesets",
+	Aliases: []string{"rs"},
+	Short:   "Rulesets engine (custom rules and phase entry points)",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var rulesetsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List rulesets for a zone or account",
+	RunE:    rulesetsList,
+}
+
+var rulesetsPhasesCmd = &cobra.Command{
+	Use:   "phases",
+	Short: "List phases and their entry point rulesets",
+	RunE:  rulesetsPhases,
+}
+
+var rulesetsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a ruleset and its rules",
+	RunE:  rulesetsShow,
+}
+
+var rulesetsRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Rules in a ruleset",
+}
+
+var rulesetsRuleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a rule to a ruleset",
+	RunE:  rulesetsRuleAdd,
+}
+
+var rulesetsRuleUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a rule in a ruleset",
+	RunE:  rulesetsRuleUpdate,
+}
+
+var rulesetsRuleDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a rule from a ruleset",
+	RunE:  rulesetsRuleDelete,
+}
+
+var rulesetsRuleReorderCmd = &cobra.Command{
+	Use:   "reorder",
+	Short: "Move a rule within a ruleset",
+	RunE:  rulesetsRuleReorder,
+}
+
+// rulesetPhases lists the phases offered by the phases command, in execution order.
+var rulesetPhases = []rulesets.Phase{
+	rulesets.PhaseDDoSL4,
+	rulesets.PhaseDDoSL7,
+	rulesets.PhaseHTTPRequestSanitize,
+	rulesets.PhaseHTTPRequestDynamicRedirect,
+	rulesets.PhaseHTTPRequestTransform,
+	rulesets.PhaseHTTPConfigSettings,
+	rulesets.PhaseHTTPRequestOrigin,
+	rulesets.PhaseHTTPRequestFirewallCustom,
+	rulesets.PhaseHTTPRatelimit,
+	rulesets.PhaseHTTPRequestFirewallManaged,
+	rulesets.PhaseHTTPRequestSBFM,
+	rulesets.PhaseHTTPRequestRedirect,
+	rulesets.PhaseHTTPRequestLateTransform,
+	rulesets.PhaseHTTPRequestCacheSettings,
+	rulesets.PhaseHTTPCustomErrors,
+	rulesets.PhaseHTTPResponseHeadersTransform,
+	rulesets.PhaseHTTPResponseCompression,
+	rulesets.PhaseHTTPResponseFirewallManaged,
+	rulesets.PhaseHTTPLogCustomFields,
+	rulesets.PhaseMagicTransit,
+	rulesets.PhaseMagicTransitIDsManaged,
+	rulesets.PhaseMagicTransitManaged,
+	rulesets.PhaseMagicTransitRatelimit,
+}
+
+func init() {
+	rootCmd.AddCommand(rulesetsCmd)
+	rulesetsCmd.AddCommand(rulesetsListCmd)
+	rulesetsCmd.AddCommand(rulesetsPhasesCmd)
+	rulesetsCmd.AddCommand(rulesetsShowCmd)
+	rulesetsCmd.AddCommand(rulesetsRulesCmd)
+	rulesetsRulesCmd.AddCommand(rulesetsRuleAddCmd)
+	rulesetsRulesCmd.AddCommand(rulesetsRuleUpdateCmd)
+	rulesetsRulesCmd.AddCommand(rulesetsRuleDeleteCmd)
+	rulesetsRulesCmd.AddCommand(rulesetsRuleReorderCmd)
+
+	for _, c := range []*cobra.Command{
+		rulesetsListCmd, rulesetsPhasesCmd, rulesetsShowCmd,
+		rulesetsRuleAddCmd, rulesetsRuleUpdateCmd, rulesetsRuleDeleteCmd, rulesetsRuleReorderCmd,
+	} {
+		c.Flags().String("zone", "", "zone name")
+		c.Flags().String("account", "", "account name")
+	}
+
+	for _, c := range []*cobra.Command{
+		rulesetsShowCmd, rulesetsRuleAddCmd, rulesetsRuleUpdateCmd, rulesetsRuleDeleteCmd, rulesetsRuleReorderCmd,
+	} {
+		c.Flags().String("id", "", "ruleset ID (overrides --phase)")
+		c.Flags().String("phase", string(rulesets.PhaseHTTPRequestFirewallCustom), "phase whose entry point ruleset is used")
+	}
+
+	// Add flags
+	rulesetsRuleAddCmd.Flags().String("expression", "", "expression matching the traffic")
+	rulesetsRuleAddCmd.Flags().String("action", "", "rule action, e.g. block, managed_challenge, skip, log")
+	rulesetsRuleAddCmd.Flags().String("action-parameters", "", "action parameters as a JSON object")
+	rulesetsRuleAddCmd.Flags().String("description", "", "rule description")
+	rulesetsRuleAddCmd.Flags().String("ref", "", "rule reference (defaults to the rule ID)")
+	rulesetsRuleAddCmd.Flags().Bool("disabled", false, "add the rule disabled")
+	addRulePositionFlags(rulesetsRuleAddCmd)
+
+	// Update flags
+	rulesetsRuleUpdateCmd.Flags().String("rule-id", "", "rule ID")
+	rulesetsRuleUpdateCmd.Flags().String("expression", "", "expression matching the traffic")
+	rulesetsRuleUpdateCmd.Flags().String("action", "", "rule action, e.g. block, managed_challenge, skip, log")
+	rulesetsRuleUpdateCmd.Flags().String("action-parameters", "", "action parameters as a JSON object")
+	rulesetsRuleUpdateCmd.Flags().String("description", "", "rule description")
+	rulesetsRuleUpdateCmd.Flags().Bool("enabled", true, "whether the rule is executed")
+
+	// Delete flags
+	rulesetsRuleDeleteCmd.Flags().String("rule-id", "", "rule ID")
+
+	// Reorder flags
+	rulesetsRuleReorderCmd.Flags().String("rule-id", "", "rule ID")
+	addRulePositionFlags(rulesetsRuleReorderCmd)
+}
+
+func addRulePositionFlags(c *cobra.Command) {
+	c.Flags().String("before", "", "place the rule before the rule with this ID")
+	c.Flags().String("after", "", "place the rule after the rule with this ID")
+	c.Flags().Int("index", 0, "place the rule at this 1-based position")
+}
+
+// getRulePosition builds the position object for a rule from the --before,
+// --after and --index flags. It returns nil if none was given.
+func getRulePosition(c *cobra.Command) (interface{}, error) {
+	before, _ := c.Flags().GetString("before")
+	after, _ := c.Flags().GetString("after")
+	index, _ := c.Flags().GetInt("index")
+
+	var position map[string]interface{}
+	set := 0
+	if before != "" {
+		position = map[string]interface{}{"before": before}
+		set++
+	}
+	if after != "" {
+		position = map[string]interface{}{"after": after}
+		set++
+	}
+	if c.Flags().Changed("index") {
+		if index < 1 {
+			return nil, errors.New("--index must be 1 or greater")
+		}
+		position = map[string]interface{}{"index": index}
+		set++
+	}
+	if set > 1 {
+		return nil, errors.New("only one of --before, --after and --index can be given")
+	}
+	if position == nil {
+		return nil, nil
+	}
+	return position, nil
+}
+
+// getRulesetScope resolves --zone or --account like getScope, but rejects the
+// user scope up front since rulesets only exist on zones and accounts.
+func getRulesetScope(c *cobra.Command) (string, string, error) {
+	accountName, _ := c.Flags().GetString("account")
+	zoneName, _ := c.Flags().GetString("zone")
+	if accountName == "" && zoneName == "" {
+		return "", "", errors.New("error: one of --zone or --account is required")
+	}
+	return getScope(c)
+}
+
+func getPhaseFlag(c *cobra.Command) (rulesets.Phase, error) {
+	p, _ := c.Flags().GetString("phase")
+	phase := rulesets.Phase(p)
+	if !phase.IsKnown() {
+		return "", fmt.Errorf("unknown phase %q", p)
+	}
+	return phase, nil
+}
+
+// toRuleset normalizes the ruleset returned by the various rulesets endpoints,
+// which all share the same shape but not the same Go type.
+func toRuleset(raw string) (*rulesets.RulesetGetResponse, error) {
+	var rs rulesets.RulesetGetResponse
+	if err := rs.UnmarshalJSON([]byte(raw)); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// getPhaseEntrypoint fetches the entry point ruleset of a phase. It returns nil
+// without an error if the phase has no entry point ruleset yet.
+func getPhaseEntrypoint(c *cobra.Command, accountID, zoneID string, phase rulesets.Phase) (*rulesets.RulesetGetResponse, error) {
+	params := rulesets.PhaseGetParams{}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+
+	res, err := client.Rulesets.Phases.Get(c.Context(), phase, params)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toRuleset(res.JSON.RawJSON())
+}
+
+// getRuleset fetches the ruleset selected by --id, or else the entry point
+// ruleset of the --phase flag.
+func getRuleset(c *cobra.Command, accountID, zoneID string) (*rulesets.RulesetGetResponse, error) {
+	id, _ := c.Flags().GetString("id")
+	if id == "" {
+		phase, err := getPhaseFlag(c)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := getPhaseEntrypoint(c, accountID, zoneID, phase)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil {
+			return nil, fmt.Errorf("phase %q has no entry point ruleset", phase)
+		}
+		return rs, nil
+	}
+
+	params := rulesets.RulesetGetParams{}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+	res, err := client.Rulesets.Get(c.Context(), id, params)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func findRulesetRule(rs *rulesets.RulesetGetResponse, ruleID string) (rulesets.RulesetGetResponseRule, bool) {
+	for _, r := range rs.Rules {
+		if r.ID == ruleID {
+			return r, true
+		}
+	}
+	return rulesets.RulesetGetResponseRule{}, false
+}
+
+// rulesetRule is the writable part of a rule, shared by the commands that
+// create or replace rules in a ruleset.
+type rulesetRule struct {
+	Action                 string
+	ActionParameters       interface{}
+	Categories             interface{}
+	ExposedCredentialCheck interface{}
+	Ratelimit              interface{}
+	Description            string
+	Expression             string
+	Enabled                bool
+	Logging                *bool
+	Ref                    string
+}
+
+// rulesetRuleFromExisting copies an existing rule so that it can be sent back
+// with only some fields changed. Nested objects are passed through as raw JSON.
+func rulesetRuleFromExisting(r rulesets.RulesetGetResponseRule) rulesetRule {
+	rule := rulesetRule{
+		Action:      string(r.Action),
+		Description: r.Description,
+		Expression:  r.Expression,
+		Enabled:     r.Enabled,
+		Ref:         r.Ref,
+	}
+	if !r.JSON.ActionParameters.IsNull() {
+		rule.ActionParameters = json.RawMessage(r.JSON.ActionParameters.Raw())
+	}
+	if !r.JSON.Categories.IsNull() {
+		rule.Categories = json.RawMessage(r.JSON.Categories.Raw())
+	}
+	if !r.JSON.ExposedCredentialCheck.IsNull() {
+		rule.ExposedCredentialCheck = json.RawMessage(r.JSON.ExposedCredentialCheck.Raw())
+	}
+	if !r.JSON.Ratelimit.IsNull() {
+		rule.Ratelimit = json.RawMessage(r.JSON.Ratelimit.Raw())
+	}
+	if !r.JSON.Logging.IsNull() {
+		logging := r.Logging.Enabled
+		rule.Logging = &logging
+	}
+	return rule
+}
+
+func (r rulesetRule) newParams(position interface{}) rulesets.RuleNewParamsBody {
+	body := rulesets.RuleNewParamsBody{
+		Action:      cloudflare.F(rulesets.RuleNewParamsBodyAction(r.Action)),
+		Expression:  cloudflare.F(r.Expression),
+		Description: cloudflare.F(r.Description),
+		Enabled:     cloudflare.F(r.Enabled),
+	}
+	if r.ActionParameters != nil {
+		body.ActionParameters = cloudflare.F(r.ActionParameters)
+	}
+	if r.Categories != nil {
+		body.Categories = cloudflare.F(r.Categories)
+	}
+	if r.ExposedCredentialCheck != nil {
+		body.ExposedCredentialCheck = cloudflare.F(r.ExposedCredentialCheck)
+	}
+	if r.Ratelimit != nil {
+		body.Ratelimit = cloudflare.F(r.Ratelimit)
+	}
+	if r.Logging != nil {
+		body.Logging = cloudflare.F(rulesets.LoggingParam{Enabled: cloudflare.F(*r.Logging)})
+	}
+	if r.Ref != "" {
+		body.Ref = cloudflare.F(r.Ref)
+	}
+	if position != nil {
+		body.Position = cloudflare.F(position)
+	}
+	return body
+}
+
+func (r rulesetRule) editParams(position interface{}) rulesets.RuleEditParamsBody {
+	body := rulesets.RuleEditParamsBody{
+		Action:      cloudflare.F(rulesets.RuleEditParamsBodyAction(r.Action)),
+		Expression:  cloudflare.F(r.Expression),
+		Description: cloudflare.F(r.Description),
+		Enabled:     cloudflare.F(r.Enabled),
+	}
+	if r.ActionParameters != nil {
+		body.ActionParameters = cloudflare.F(r.ActionParameters)
+	}
+	if r.Categories != nil {
+		body.Categories = cloudflare.F(r.Categories)
+	}
+	if r.ExposedCredentialCheck != nil {
+		body.ExposedCredentialCheck = cloudflare.F(r.ExposedCredentialCheck)
+	}
+	if r.Ratelimit != nil {
+		body.Ratelimit = cloudflare.F(r.Ratelimit)
+	}
+	if r.Logging != nil {
+		body.Logging = cloudflare.F(rulesets.LoggingParam{Enabled: cloudflare.F(*r.Logging)})
+	}
+	if r.Ref != "" {
+		body.Ref = cloudflare.F(r.Ref)
+	}
+	if position != nil {
+		body.Position = cloudflare.F(position)
+	}
+	return body
+}
+
+func (r rulesetRule) phaseParams() rulesets.PhaseUpdateParamsRule {
+	body := rulesets.PhaseUpdateParamsRule{
+		Action:      cloudflare.F(rulesets.PhaseUpdateParamsRulesAction(r.Action)),
+		Expression:  cloudflare.F(r.Expression),
+		Description: cloudflare.F(r.Description),
+		Enabled:     cloudflare.F(r.Enabled),
+	}
+	if r.ActionParameters != nil {
+		body.ActionParameters = cloudflare.F(r.ActionParameters)
+	}
+	if r.Categories != nil {
+		body.Categories = cloudflare.F(r.Categories)
+	}
+	if r.ExposedCredentialCheck != nil {
+		body.ExposedCredentialCheck = cloudflare.F(r.ExposedCredentialCheck)
+	}
+	if r.Ratelimit != nil {
+		body.Ratelimit = cloudflare.F(r.Ratelimit)
+	}
+	if r.Logging != nil {
+		body.Logging = cloudflare.F(rulesets.LoggingParam{Enabled: cloudflare.F(*r.Logging)})
+	}
+	if r.Ref != "" {
+		body.Ref = cloudflare.F(r.Ref)
+	}
+	return body
+}
+
+// addPhaseRule adds a rule to the entry point ruleset of a phase, creating the
+// entry point ruleset if the phase does not have one yet.
+func addPhaseRule(c *cobra.Command, accountID, zoneID string, phase rulesets.Phase, rule rulesetRule, position interface{}) (*rulesets.RulesetGetResponse, error) {
+	rs, err := getPhaseEntrypoint(c, accountID, zoneID, phase)
+	if err != nil {
+		return nil, err
+	}
+	if rs != nil {
+		return addRulesetRule(c, accountID, zoneID, rs.ID, rule, position)
+	}
+
+	params := rulesets.PhaseUpdateParams{
+		Rules: cloudflare.F([]rulesets.PhaseUpdateParamsRuleUnion{rule.phaseParams()}),
+	}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+	res, err := client.Rulesets.Phases.Update(c.Context(), phase, params)
+	if err != nil {
+		return nil, err
+	}
+	return toRuleset(res.JSON.RawJSON())
+}
+
+func addRulesetRule(c *cobra.Command, accountID, zoneID, rulesetID string, rule rulesetRule, position interface{}) (*rulesets.RulesetGetResponse, error) {
+	params := rulesets.RuleNewParams{
+		Body: rule.newParams(position),
+	}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+	res, err := client.Rulesets.Rules.New(c.Context(), rulesetID, params)
+	if err != nil {
+		return nil, err
+	}
+	return toRuleset(res.JSON.RawJSON())
+}
+
+func editRulesetRule(c *cobra.Command, accountID, zoneID, rulesetID, ruleID string, rule rulesetRule, position interface{}) (*rulesets.RulesetGetResponse, error) {
+	params := rulesets.RuleEditParams{
+		Body: rule.editParams(position),
+	}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+	res, err := client.Rulesets.Rules.Edit(c.Context(), rulesetID, ruleID, params)
+	if err != nil {
+		return nil, err
+	}
+	return toRuleset(res.JSON.RawJSON())
+}
+
+func deleteRulesetRule(c *cobra.Command, accountID, zoneID, rulesetID, ruleID string) (*rulesets.RulesetGetResponse, error) {
+	params := rulesets.RuleDeleteParams{}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+	res, err := client.Rulesets.Rules.Delete(c.Context(), rulesetID, ruleID, params)
+	if err != nil {
+		return nil, err
+	}
+	return toRuleset(res.JSON.RawJSON())
+}
+
+// validateExpression performs a local sanity check of a rule expression: it
+// must not be empty, string literals must be terminated and parentheses,
+// brackets and braces must be balanced. Full validation is left to the API.
+func validateExpression(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return errors.New("expression is empty")
+	}
+
+	closing := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	var stack []rune
+	var offsets []int
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '"':
+			start := i
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return fmt.Errorf("unterminated string starting at offset %d", start)
+			}
+		case r == 'r' && (i == 0 || !isIdentRune(runes[i-1])) && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '#'):
+			// Raw string: r"..." or r#"..."#, with any number of hashes.
+			start := i
+			hashes := 0
+			for i++; i < len(runes) && runes[i] == '#'; i++ {
+				hashes++
+			}
+			if i >= len(runes) || runes[i] != '"' {
+				return fmt.Errorf("malformed raw string at offset %d", start)
+			}
+			terminator := "\"" + strings.Repeat("#", hashes)
+			rest := string(runes[i+1:])
+			end := strings.Index(rest, terminator)
+			if end < 0 {
+				return fmt.Errorf("unterminated raw string starting at offset %d", start)
+			}
+			i += utf8.RuneCountInString(rest[:end]) + len(terminator)
+		case r == '(' || r == '[' || r == '{':
+			stack = append(stack, r)
+			offsets = append(offsets, i)
+		case r == ')' || r == ']' || r == '}':
+			if len(stack) == 0 || stack[len(stack)-1] != closing[r] {
+				return fmt.Errorf("unexpected %q at offset %d", r, i)
+			}
+			stack = stack[:len(stack)-1]
+			offsets = offsets[:len(offsets)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q at offset %d", stack[len(stack)-1], offsets[len(offsets)-1])
+	}
+	return nil
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func formatRulesetRule(id, action, description, expression string, enabled bool) []string {
+	return []string{
+		id,
+		action,
+		formatBool(enabled),
+		description,
+		expression,
+	}
+}
+
+// writeRuleset prints a ruleset followed by its rules, or the ruleset as JSON.
+func writeRuleset(c *cobra.Command, rs *rulesets.RulesetGetResponse) error {
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(rs.JSON.RawJSON()))
+	}
+
+	writeTable([][]string{{
+		rs.ID,
+		rs.Name,
+		string(rs.Kind),
+		string(rs.Phase),
+		rs.Version,
+	}}, "ID", "Name", "Kind", "Phase", "Version")
+
+	output := make([][]string, 0, len(rs.Rules))
+	for _, r := range rs.Rules {
+		output = append(output, formatRulesetRule(r.ID, string(r.Action), r.Description, r.Expression, r.Enabled))
+	}
+	writeTable(output, "Rule ID", "Action", "Enabled", "Description", "Expression")
+	return nil
+}
+
+func rulesetsList(c *cobra.Command, args []string) error {
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+
+	params := rulesets.RulesetListParams{}
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+	}
+
+	var list []rulesets.RulesetListResponse
+	pager := client.Rulesets.ListAutoPaging(c.Context(), params)
+	for pager.Next() {
+		list = append(list, pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(list)
+	}
+
+	output := make([][]string, 0, len(list))
+	for _, rs := range list {
+		output = append(output, []string{
+			rs.ID,
+			rs.Name,
+			string(rs.Kind),
+			string(rs.Phase),
+			rs.Version,
+			rs.LastUpdated.Format(time.RFC3339),
+		})
+	}
+	writeTable(output, "ID", "Name", "Kind", "Phase", "Version", "Last Updated")
+	return nil
+}
+
+func rulesetsPhases(c *cobra.Command, args []string) error {
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+
+	params := rulesets.RulesetListParams{}
+	entrypointKind := rulesets.KindRoot
+	if accountID != "" {
+		params.AccountID = cloudflare.F(accountID)
+	}
+	if zoneID != "" {
+		params.ZoneID = cloudflare.F(zoneID)
+		entrypointKind = rulesets.KindZone
+	}
+
+	entrypoints := make(map[rulesets.Phase]string)
+	pager := client.Rulesets.ListAutoPaging(c.Context(), params)
+	for pager.Next() {
+		rs := pager.Current()
+		if rs.Kind == entrypointKind {
+			entrypoints[rs.Phase] = rs.ID
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(entrypoints)
+	}
+
+	output := make([][]string, 0, len(rulesetPhases))
+	for _, phase := range rulesetPhases {
+		output = append(output, []string{string(phase), entrypoints[phase]})
+	}
+	writeTable(output, "Phase", "Entry Point Ruleset")
+	return nil
+}
+
+func rulesetsShow(c *cobra.Command, args []string) error {
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getRuleset(c, accountID, zoneID)
+	if err != nil {
+		return err
+	}
+	return writeRuleset(c, rs)
+}
+
+// parseActionParameters decodes the --action-parameters flag, which must hold
+// a JSON object.
+func parseActionParameters(c *cobra.Command) (interface{}, error) {
+	raw, _ := c.Flags().GetString("action-parameters")
+	if raw == "" {
+		return nil, nil
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		return nil, fmt.Errorf("invalid --action-parameters: %w", err)
+	}
+	return params, nil
+}
+
+func checkRuleAction(action string) error {
+	if !rulesets.RuleNewParamsBodyAction(action).IsKnown() {
+		return fmt.Errorf("unknown rule action %q", action)
+	}
+	return nil
+}
+
+func rulesetsRuleAdd(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "expression", "action"); err != nil {
+		return err
+	}
+
+	expression, _ := c.Flags().GetString("expression")
+	action, _ := c.Flags().GetString("action")
+	description, _ := c.Flags().GetString("description")
+	ref, _ := c.Flags().GetString("ref")
+	disabled, _ := c.Flags().GetBool("disabled")
+
+	if err := validateExpression(expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	if err := checkRuleAction(action); err != nil {
+		return err
+	}
+	actionParameters, err := parseActionParameters(c)
+	if err != nil {
+		return err
+	}
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+
+	rule := rulesetRule{
+		Action:           action,
+		ActionParameters: actionParameters,
+		Description:      description,
+		Expression:       expression,
+		Enabled:          !disabled,
+		Ref:              ref,
+	}
+
+	var rs *rulesets.RulesetGetResponse
+	if id, _ := c.Flags().GetString("id"); id != "" {
+		rs, err = addRulesetRule(c, accountID, zoneID, id, rule, position)
+	} else {
+		phase, perr := getPhaseFlag(c)
+		if perr != nil {
+			return perr
+		}
+		rs, err = addPhaseRule(c, accountID, zoneID, phase, rule, position)
+	}
+	if err != nil {
+		return fmt.Errorf("Error adding rule: %w", err)
+	}
+	return writeRuleset(c, rs)
+}
+
+func rulesetsRuleUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "rule-id"); err != nil {
+		return err
+	}
+	ruleID, _ := c.Flags().GetString("rule-id")
+
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+	rs, err := getRuleset(c, accountID, zoneID)
+	if err != nil {
+		return err
+	}
+	existing, ok := findRulesetRule(rs, ruleID)
+	if !ok {
+		return fmt.Errorf("rule %q not found in ruleset %s", ruleID, rs.ID)
+	}
+
+	// The API replaces the whole rule, so start from the current definition
+	// and only overwrite what was given on the command line.
+	rule := rulesetRuleFromExisting(existing)
+	if c.Flags().Changed("expression") {
+		rule.Expression, _ = c.Flags().GetString("expression")
+		if err := validateExpression(rule.Expression); err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+	}
+	if c.Flags().Changed("action") {
+		rule.Action, _ = c.Flags().GetString("action")
+		if err := checkRuleAction(rule.Action); err != nil {
+			return err
+		}
+		// Parameters of the previous action do not apply to the new one.
+		rule.ActionParameters = nil
+	}
+	if c.Flags().Changed("action-parameters") {
+		rule.ActionParameters, err = parseActionParameters(c)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Flags().Changed("description") {
+		rule.Description, _ = c.Flags().GetString("description")
+	}
+	if c.Flags().Changed("enabled") {
+		rule.Enabled, _ = c.Flags().GetBool("enabled")
+	}
+
+	rs, err = editRulesetRule(c, accountID, zoneID, rs.ID, ruleID, rule, nil)
+	if err != nil {
+		return fmt.Errorf("Error updating rule: %w", err)
+	}
+	return writeRuleset(c, rs)
+}
+
+func rulesetsRuleDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "rule-id"); err != nil {
+		return err
+	}
+	ruleID, _ := c.Flags().GetString("rule-id")
+
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+	rs, err := getRuleset(c, accountID, zoneID)
+	if err != nil {
+		return err
+	}
+
+	rs, err = deleteRulesetRule(c, accountID, zoneID, rs.ID, ruleID)
+	if err != nil {
+		return fmt.Errorf("Error deleting rule: %w", err)
+	}
+	return writeRuleset(c, rs)
+}
+
+func rulesetsRuleReorder(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "rule-id"); err != nil {
+		return err
+	}
+	ruleID, _ := c.Flags().GetString("rule-id")
+
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+	if position == nil {
+		return errors.New("error: one of --before, --after or --index is required")
+	}
+
+	accountID, zoneID, err := getRulesetScope(c)
+	if err != nil {
+		return err
+	}
+	rs, err := getRuleset(c, accountID, zoneID)
+	if err != nil {
+		return err
+	}
+	existing, ok := findRulesetRule(rs, ruleID)
+	if !ok {
+		return fmt.Errorf("rule %q not found in ruleset %s", ruleID, rs.ID)
+	}
+
+	rs, err = editRulesetRule(c, accountID, zoneID, rs.ID, ruleID, rulesetRuleFromExisting(existing), position)
+	if err != nil {
+		return fmt.Errorf("Error moving rule: %w", err)
+	}
+	return writeRuleset(c, rs)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import "testing"
+
+func TestValidateExpression(t *testing.T) {
+	valid := []string{
+		`http.request.uri.path eq "/login"`,
+		`(ip.src in {192.0.2.0/24 198.51.100.1}) and not cf.client.bot`,
+		`any(http.request.headers["x-test"][*] == "a)b")`,
+		`http.request.uri.path matches r"^/api/(v1|v2)\("`,
+		`http.host eq r#"quoted "(" host"#`,
+		`http.user_agent contains "esc\"aped ("`,
+	}
+	for _, expr := range valid {
+		if err := validateExpression(expr); err != nil {
+			t.Errorf("validateExpression(%q) = %v; want nil", expr, err)
+		}
+	}
+
+	invalid := []string{
+		``,
+		`   `,
+		`(http.host eq "example.com"`,
+		`http.host eq "example.com")`,
+		`ip.src in {192.0.2.1]`,
+		`http.host eq "example.com`,
+		`http.host eq r#"example.com"`,
+	}
+	for _, expr := range invalid {
+		if err := validateExpression(expr); err == nil {
+			t.Errorf("validateExpression(%q) = nil; want error", expr)
+		}
+	}
+}
+
+func TestRulesetsCmd(t *testing.T) {
+	want := map[string]bool{"list": false, "phases": false, "show": false, "rules": false}
+	for _, c := range rulesetsCmd.Commands() {
+		if _, ok := want[c.Name()]; ok {
+			want[c.Name()] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("rulesets %s subcommand not found", name)
+		}
+	}
+}
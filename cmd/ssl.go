@@ -0,0 +1,442 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/ssl"
+	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var sslCmd = &cobra.Command{
+	Use:   "ssl",
+	Short: "Edge certificates and SSL/TLS settings",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var sslModeCmd = &cobra.Command{
+	Use:   "mode",
+	Short: "Show or set the SSL/TLS encryption mode of a zone",
+	Long: `Show the SSL/TLS encryption mode of a zone, or set it with --value to one of
+off, flexible, full or strict.`,
+	RunE: sslMode,
+}
+
+var sslUniversalCmd = &cobra.Command{
+	Use:   "universal",
+	Short: "Show or set whether Universal SSL is enabled for a zone",
+	RunE:  sslUniversal,
+}
+
+var sslVerificationCmd = &cobra.Command{
+	Use:   "verification",
+	Short: "Show the domain control validation status of the certificates of a zone",
+	RunE:  sslVerification,
+}
+
+var sslPacksCmd = &cobra.Command{
+	Use:   "packs",
+	Short: "Certificate packs",
+}
+
+var sslPacksListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the certificate packs of a zone",
+	RunE:    sslPacksList,
+}
+
+var sslPacksOrderCmd = &cobra.Command{
+	Use:   "order",
+	Short: "Order an advanced certificate pack",
+	RunE:  sslPacksOrder,
+}
+
+var sslPacksDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an advanced certificate pack",
+	RunE:  sslPacksDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(sslCmd)
+	sslCmd.AddCommand(sslModeCmd)
+	sslCmd.AddCommand(sslUniversalCmd)
+	sslCmd.AddCommand(sslVerificationCmd)
+	sslCmd.AddCommand(sslPacksCmd)
+	sslPacksCmd.AddCommand(sslPacksListCmd)
+	sslPacksCmd.AddCommand(sslPacksOrderCmd)
+	sslPacksCmd.AddCommand(sslPacksDeleteCmd)
+
+	sslModeCmd.Flags().String("zone", "", "zone name")
+	sslModeCmd.Flags().String("value", "", "new mode: off, flexible, full or strict")
+
+	sslUniversalCmd.Flags().String("zone", "", "zone name")
+	sslUniversalCmd.Flags().String("value", "", "on or off")
+
+	sslVerificationCmd.Flags().String("zone", "", "zone name")
+	sslVerificationCmd.Flags().Bool("retry", false, "immediately retry validation of pending certificates")
+
+	sslPacksListCmd.Flags().String("zone", "", "zone name")
+	sslPacksListCmd.Flags().Bool("all", false, "include inactive and deleted packs")
+
+	sslPacksOrderCmd.Flags().String("zone", "", "zone name")
+	sslPacksOrderCmd.Flags().StringArray("host", nil, "hostname to cover; the zone apex is required (repeatable)")
+	sslPacksOrderCmd.Flags().String("ca", "lets_encrypt", "certificate authority: google, lets_encrypt or ssl_com")
+	sslPacksOrderCmd.Flags().String("validation-method", "txt", "validation method: txt, http or email")
+	sslPacksOrderCmd.Flags().Int("validity", 90, "validity in days: 14, 30, 90 or 365")
+	sslPacksOrderCmd.Flags().Bool("cloudflare-branding", false, "add sni.cloudflaressl.com as common name")
+
+	sslPacksDeleteCmd.Flags().String("zone", "", "zone name")
+	sslPacksDeleteCmd.Flags().String("id", "", "certificate pack ID")
+}
+
+func sslMode(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+
+	var value interface{}
+	if c.Flags().Changed("value") {
+		raw, _ := c.Flags().GetString("value")
+		var err error
+		if value, err = parseZoneSettingValue("ssl", raw); err != nil {
+			return err
+		}
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var raw string
+	if value != nil {
+		res, err := editZoneSetting(c, zoneID, "ssl", value)
+		if err != nil {
+			return err
+		}
+		raw = res.JSON.RawJSON()
+	} else {
+		res, err := client.Zones.Settings.Get(c.Context(), "ssl", zones.SettingGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting SSL mode: %w", err)
+		}
+		raw = res.JSON.RawJSON()
+	}
+
+	s, err := zoneSettingFromRaw(raw)
+	if err != nil {
+		return err
+	}
+	return writeZoneSettings(c, []zoneSetting{s})
+}
+
+func sslUniversal(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	value, _ := c.Flags().GetString("value")
+	if c.Flags().Changed("value") && value != "on" && value != "off" {
+		return fmt.Errorf("invalid value %q: must be on or off", value)
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var res *ssl.UniversalSSLSettings
+	if c.Flags().Changed("value") {
+		res, err = client.SSL.Universal.Settings.Edit(c.Context(), ssl.UniversalSettingEditParams{
+			ZoneID: cloudflare.F(zoneID),
+			UniversalSSLSettings: ssl.UniversalSSLSettingsParam{
+				Enabled: cloudflare.F(value == "on"),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating Universal SSL: %w", err)
+		}
+	} else {
+		res, err = client.SSL.Universal.Settings.Get(c.Context(), ssl.UniversalSettingGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting Universal SSL: %w", err)
+		}
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+	}
+	writeTable([][]string{{zoneName, formatBool(res.Enabled)}}, "Zone", "Universal SSL")
+	return nil
+}
+
+// verificationRecord returns the DCV record of a verification as name and
+// target, whichever fields the validation method uses.
+func verificationRecord(v ssl.Verification) (string, string) {
+	info := map[string]interface{}{}
+	if !v.JSON.VerificationInfo.IsNull() {
+		_ = json.Unmarshal([]byte(v.JSON.VerificationInfo.Raw()), &info)
+	}
+	var name, target string
+	for _, k := range []string{"record_name", "http_url", "txt_name", "cname"} {
+		if s, ok := info[k].(string); ok && s != "" {
+			name = s
+			break
+		}
+	}
+	for _, k := range []string{"record_target", "http_body", "txt_value", "cname_target"} {
+		if s, ok := info[k].(string); ok && s != "" {
+			target = s
+			break
+		}
+	}
+	if emails, ok := info["emails"].([]interface{}); ok && target == "" {
+		var addresses []string
+		for _, e := range emails {
+			if s, ok := e.(string); ok {
+				addresses = append(addresses, s)
+			}
+		}
+		target = strings.Join(addresses, ", ")
+	}
+	return name, target
+}
+
+func sslVerification(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	retry, _ := c.Flags().GetBool("retry")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := ssl.VerificationGetParams{ZoneID: cloudflare.F(zoneID)}
+	if retry {
+		params.Retry = cloudflare.F(ssl.VerificationGetParamsRetryTrue)
+	}
+	res, err := client.SSL.Verification.Get(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error getting SSL verification: %w", err)
+	}
+
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(*res))
+		for _, v := range *res {
+			raw = append(raw, json.RawMessage(v.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(*res))
+	for _, v := range *res {
+		name, target := verificationRecord(v)
+		output = append(output, []string{
+			v.CERTPackUUID,
+			string(v.CertificateStatus),
+			string(v.ValidationMethod),
+			formatBool(v.VerificationStatus),
+			name,
+			target,
+		})
+	}
+	writeTable(output, "Certificate Pack", "Status", "Method", "Verified", "Record", "Value")
+	return nil
+}
+
+// packExpiry returns the earliest expiry of the certificates of a pack.
+func packExpiry(p ssl.CertificatePackListResponse) time.Time {
+	var expiry time.Time
+	for _, cert := range p.Certificates {
+		if !cert.ExpiresOn.IsZero() && (expiry.IsZero() || cert.ExpiresOn.Before(expiry)) {
+			expiry = cert.ExpiresOn
+		}
+	}
+	return expiry
+}
+
+func formatCertificatePack(p ssl.CertificatePackListResponse) []string {
+	expires := ""
+	if e := packExpiry(p); !e.IsZero() {
+		expires = e.Format(time.RFC3339)
+	}
+	return []string{
+		p.ID,
+		string(p.Type),
+		strings.Join(p.Hosts, ", "),
+		string(p.Status),
+		string(p.CertificateAuthority),
+		string(p.ValidationMethod),
+		expires,
+	}
+}
+
+func sslPacksList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	all, _ := c.Flags().GetBool("all")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := ssl.CertificatePackListParams{ZoneID: cloudflare.F(zoneID)}
+	if all {
+		params.Status = cloudflare.F(ssl.CertificatePackListParamsStatusAll)
+	}
+	var packs []ssl.CertificatePackListResponse
+	iter := client.SSL.CertificatePacks.ListAutoPaging(c.Context(), params)
+	for iter.Next() {
+		packs = append(packs, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing certificate packs: %w", err)
+	}
+
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(packs))
+		for _, p := range packs {
+			raw = append(raw, json.RawMessage(p.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(packs))
+	for _, p := range packs {
+		output = append(output, formatCertificatePack(p))
+	}
+	writeTable(output, "ID", "Type", "Hosts", "Status", "CA", "Validation", "Expires On")
+	return nil
+}
+
+// certificatePackOrder checks the flags of an advanced certificate pack order.
+func certificatePackOrder(c *cobra.Command, zoneName string) (ssl.CertificatePackNewParams, error) {
+	hosts, _ := c.Flags().GetStringArray("host")
+	ca, _ := c.Flags().GetString("ca")
+	method, _ := c.Flags().GetString("validation-method")
+	validity, _ := c.Flags().GetInt("validity")
+	branding, _ := c.Flags().GetBool("cloudflare-branding")
+
+	if len(hosts) == 0 {
+		hosts = []string{zoneName, "*." + zoneName}
+	}
+	apex := false
+	for _, h := range hosts {
+		if h == zoneName {
+			apex = true
+		} else if !strings.HasSuffix(h, "."+zoneName) {
+			return ssl.CertificatePackNewParams{}, fmt.Errorf("host %q is not in zone %s", h, zoneName)
+		}
+	}
+	if !apex {
+		return ssl.CertificatePackNewParams{}, fmt.Errorf("the zone apex %s must be one of the hosts", zoneName)
+	}
+	if len(hosts) > 50 {
+		return ssl.CertificatePackNewParams{}, errors.New("a certificate pack covers at most 50 hosts")
+	}
+
+	if !ssl.CertificatePackNewParamsCertificateAuthority(ca).IsKnown() {
+		return ssl.CertificatePackNewParams{}, fmt.Errorf("invalid --ca %q: must be google, lets_encrypt or ssl_com", ca)
+	}
+	if !ssl.CertificatePackNewParamsValidationMethod(method).IsKnown() {
+		return ssl.CertificatePackNewParams{}, fmt.Errorf("invalid --validation-method %q: must be txt, http or email", method)
+	}
+	if !ssl.CertificatePackNewParamsValidityDays(validity).IsKnown() {
+		return ssl.CertificatePackNewParams{}, fmt.Errorf("invalid --validity %d: must be 14, 30, 90 or 365", validity)
+	}
+	if method == "http" {
+		for _, h := range hosts {
+			if strings.HasPrefix(h, "*.") {
+				return ssl.CertificatePackNewParams{}, errors.New("http validation cannot be used for wildcard hosts")
+			}
+		}
+	}
+
+	return ssl.CertificatePackNewParams{
+		CertificateAuthority: cloudflare.F(ssl.CertificatePackNewParamsCertificateAuthority(ca)),
+		Hosts:                cloudflare.F(hosts),
+		Type:                 cloudflare.F(ssl.CertificatePackNewParamsTypeAdvanced),
+		ValidationMethod:     cloudflare.F(ssl.CertificatePackNewParamsValidationMethod(method)),
+		ValidityDays:         cloudflare.F(ssl.CertificatePackNewParamsValidityDays(validity)),
+		CloudflareBranding:   cloudflare.F(branding),
+	}, nil
+}
+
+func sslPacksOrder(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+
+	params, err := certificatePackOrder(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	params.ZoneID = cloudflare.F(zoneID)
+
+	res, err := client.SSL.CertificatePacks.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error ordering certificate pack: %w", err)
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+	}
+	writeTable([][]string{{
+		res.ID,
+		strings.Join(res.Hosts, ", "),
+		string(res.Status),
+		string(res.CertificateAuthority),
+		string(res.ValidationMethod),
+		strconv.Itoa(int(res.ValidityDays)),
+	}}, "ID", "Hosts", "Status", "CA", "Validation", "Validity")
+	return nil
+}
+
+func sslPacksDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.SSL.CertificatePacks.Delete(c.Context(), id, ssl.CertificatePackDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting certificate pack: %w", err)
+	}
+	fmt.Printf("Deleted certificate pack %s\n", res.ID)
+	return nil
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/custom_certificates"
+	"github.com/cloudflare/cloudflare-go/v6/custom_hostnames"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var sslCustomCmd = &cobra.Command{
+	Use:   "custom",
+	Short: "Custom certificates uploaded to the edge",
+}
+
+var sslCustomListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List the custom certificates of a zone",
+	RunE:    sslCustomList,
+}
+
+var sslCustomUploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload a custom certificate and its private key",
+	RunE:  sslCustomUpload,
+}
+
+var sslCustomUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Replace the certificate or change the settings of a custom certificate",
+	RunE:  sslCustomUpdate,
+}
+
+var sslCustomDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a custom certificate",
+	RunE:  sslCustomDelete,
+}
+
+func init() {
+	sslCmd.AddCommand(sslCustomCmd)
+	sslCustomCmd.AddCommand(sslCustomListCmd)
+	sslCustomCmd.AddCommand(sslCustomUploadCmd)
+	sslCustomCmd.AddCommand(sslCustomUpdateCmd)
+	sslCustomCmd.AddCommand(sslCustomDeleteCmd)
+
+	sslCustomListCmd.Flags().String("zone", "", "zone name")
+
+	addCustomCertificateFlags(sslCustomUploadCmd)
+
+	addCustomCertificateFlags(sslCustomUpdateCmd)
+	sslCustomUpdateCmd.Flags().String("id", "", "custom certificate ID")
+
+	sslCustomDeleteCmd.Flags().String("zone", "", "zone name")
+	sslCustomDeleteCmd.Flags().String("id", "", "custom certificate ID")
+}
+
+func addCustomCertificateFlags(c *cobra.Command) {
+	c.Flags().String("zone", "", "zone name")
+	c.Flags().String("cert", "", "PEM file with the certificate and any intermediates")
+	c.Flags().String("key", "", "PEM file with the private key")
+	c.Flags().String("bundle-method", "", "chain to serve: ubiquitous, optimal or force")
+	c.Flags().String("geo-restriction", "", "where to store the private key: us, eu or highest_security")
+	c.Flags().String("policy", "", "Geo Key Manager policy, e.g. (country: US) or (region: EU)")
+}
+
+// loadCertificateKeyPair reads a certificate and key from PEM files and checks
+// locally that the key belongs to the certificate. It returns the PEM data and
+// the leaf certificate.
+func loadCertificateKeyPair(certFile, keyFile string) (string, string, *x509.Certificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return "", "", nil, err
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if _, err := parseCertificates(certPEM); err != nil {
+		return "", "", nil, fmt.Errorf("%s: %w", certFile, err)
+	}
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%s and %s: %w", certFile, keyFile, err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return "", "", nil, err
+	}
+	if time.Now().After(leaf.NotAfter) {
+		return "", "", nil, fmt.Errorf("%s expired on %s", certFile, leaf.NotAfter.Format("2006-01-02"))
+	}
+	return string(certPEM), string(keyPEM), leaf, nil
+}
+
+// warnCertificateExpiry warns on stderr about a certificate that expires in
+// less than 30 days.
+func warnCertificateExpiry(certFile string, leaf *x509.Certificate) {
+	if time.Until(leaf.NotAfter) < 30*24*time.Hour {
+		fmt.Fprintf(os.Stderr, "Warning: %s expires on %s\n", certFile, leaf.NotAfter.Format("2006-01-02"))
+	}
+}
+
+func checkBundleMethod(method string) error {
+	if method != "" && !custom_hostnames.BundleMethod(method).IsKnown() {
+		return fmt.Errorf("invalid --bundle-method %q: must be ubiquitous, optimal or force", method)
+	}
+	return nil
+}
+
+func checkGeoRestriction(label string) error {
+	if label != "" && !custom_certificates.GeoRestrictionsLabel(label).IsKnown() {
+		return fmt.Errorf("invalid --geo-restriction %q: must be us, eu or highest_security", label)
+	}
+	return nil
+}
+
+func formatCustomCertificate(cert custom_certificates.CustomCertificate) []string {
+	return []string{
+		cert.ID,
+		strings.Join(cert.Hosts, ", "),
+		string(cert.Status),
+		cert.Issuer,
+		string(cert.BundleMethod),
+		cert.ExpiresOn.Format(time.RFC3339),
+	}
+}
+
+func writeCustomCertificates(c *cobra.Command, certs []custom_certificates.CustomCertificate) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(certs))
+		for _, cert := range certs {
+			raw = append(raw, json.RawMessage(cert.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(certs))
+	for _, cert := range certs {
+		output = append(output, formatCustomCertificate(cert))
+	}
+	writeTable(output, "ID", "Hosts", "Status", "Issuer", "Bundle Method", "Expires On")
+	return nil
+}
+
+func sslCustomList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	var certs []custom_certificates.CustomCertificate
+	iter := client.CustomCertificates.ListAutoPaging(c.Context(), custom_certificates.CustomCertificateListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		certs = append(certs, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing custom certificates: %w", err)
+	}
+	return writeCustomCertificates(c, certs)
+}
+
+func sslCustomUpload(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "cert", "key"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	certFile, _ := c.Flags().GetString("cert")
+	keyFile, _ := c.Flags().GetString("key")
+	bundleMethod, _ := c.Flags().GetString("bundle-method")
+	geo, _ := c.Flags().GetString("geo-restriction")
+	policy, _ := c.Flags().GetString("policy")
+
+	if err := checkBundleMethod(bundleMethod); err != nil {
+		return err
+	}
+	if err := checkGeoRestriction(geo); err != nil {
+		return err
+	}
+	certPEM, keyPEM, leaf, err := loadCertificateKeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	warnCertificateExpiry(certFile, leaf)
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := custom_certificates.CustomCertificateNewParams{
+		ZoneID:      cloudflare.F(zoneID),
+		Certificate: cloudflare.F(certPEM),
+		PrivateKey:  cloudflare.F(keyPEM),
+	}
+	if bundleMethod != "" {
+		params.BundleMethod = cloudflare.F(custom_hostnames.BundleMethod(bundleMethod))
+	}
+	if geo != "" {
+		params.GeoRestrictions = cloudflare.F(custom_certificates.GeoRestrictionsParam{
+			Label: cloudflare.F(custom_certificates.GeoRestrictionsLabel(geo)),
+		})
+	}
+	if policy != "" {
+		params.Policy = cloudflare.F(policy)
+	}
+
+	cert, err := client.CustomCertificates.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error uploading custom certificate: %w", err)
+	}
+	return writeCustomCertificates(c, []custom_certificates.CustomCertificate{*cert})
+}
+
+func sslCustomUpdate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+	certFile, _ := c.Flags().GetString("cert")
+	keyFile, _ := c.Flags().GetString("key")
+	bundleMethod, _ := c.Flags().GetString("bundle-method")
+	geo, _ := c.Flags().GetString("geo-restriction")
+
+	if err := checkBundleMethod(bundleMethod); err != nil {
+		return err
+	}
+	if err := checkGeoRestriction(geo); err != nil {
+		return err
+	}
+	if (certFile == "") != (keyFile == "") {
+		return errors.New("--cert and --key must be given together")
+	}
+
+	params := custom_certificates.CustomCertificateEditParams{}
+	if certFile != "" {
+		certPEM, keyPEM, leaf, err := loadCertificateKeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		warnCertificateExpiry(certFile, leaf)
+		params.Certificate = cloudflare.F(certPEM)
+		params.PrivateKey = cloudflare.F(keyPEM)
+	}
+	if bundleMethod != "" {
+		params.BundleMethod = cloudflare.F(custom_hostnames.BundleMethod(bundleMethod))
+	}
+	if geo != "" {
+		params.GeoRestrictions = cloudflare.F(custom_certificates.GeoRestrictionsParam{
+			Label: cloudflare.F(custom_certificates.GeoRestrictionsLabel(geo)),
+		})
+	}
+	if c.Flags().Changed("policy") {
+		policy, _ := c.Flags().GetString("policy")
+		params.Policy = cloudflare.F(policy)
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	params.ZoneID = cloudflare.F(zoneID)
+
+	cert, err := client.CustomCertificates.Edit(c.Context(), id, params)
+	if err != nil {
+		return fmt.Errorf("Error updating custom certificate: %w", err)
+	}
+	return writeCustomCertificates(c, []custom_certificates.CustomCertificate{*cert})
+}
+
+func sslCustomDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.CustomCertificates.Delete(c.Context(), id, custom_certificates.CustomCertificateDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting custom certificate: %w", err)
+	}
+	fmt.Printf("Deleted custom certificate %s\n", res.ID)
+	return nil
+}
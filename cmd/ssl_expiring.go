@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/custom_certificates"
+	"github.com/cloudflare/cloudflare-go/v6/origin_ca_certificates"
+	"github.com/cloudflare/cloudflare-go/v6/ssl"
+	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/spf13/cobra"
+)
+
+var sslExpiringCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "Report certificates of all zones that expire soon",
+	Long: `Report the custom certificates, certificate packs and Origin CA certificates
+of all zones that expire within the given time, or have already expired.
+
+The exit code is 2 when certificates expire, even if some checks failed, so
+that a check the token has no permission for does not hide them. Otherwise it
+is 1 when the certificates of some zones could not be checked and 0 when
+nothing expires.`,
+	RunE: sslExpiring,
+}
+
+func init() {
+	sslCmd.AddCommand(sslExpiringCmd)
+	sslExpiringCmd.Flags().String("within", "30d", "report certificates expiring within this time, in days (30d) or as a duration (72h)")
+	sslExpiringCmd.Flags().String("account", "", "only check the zones of this account (name or ID)")
+	sslExpiringCmd.Flags().Int("concurrency", 4, "zones to check at the same time")
+}
+
+// expiringCertificate is a certificate of any kind with its expiry.
+type expiringCertificate struct {
+	Zone      string    `json:"zone"`
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Hosts     []string  `json:"hosts"`
+	Status    string    `json:"status,omitempty"`
+	ExpiresOn time.Time `json:"expires_on"`
+	DaysLeft  int       `json:"days_left"`
+}
+
+type expiringCheckError struct {
+	Zone  string `json:"zone"`
+	Kind  string `json:"kind"`
+	Error string `json:"error"`
+}
+
+// expiringReport is the --json output, meant for alerting jobs.
+type expiringReport struct {
+	CheckedAt    time.Time             `json:"checked_at"`
+	Within       string                `json:"within"`
+	Zones        int                   `json:"zones"`
+	Certificates []expiringCertificate `json:"certificates"`
+	Errors       []expiringCheckError  `json:"errors,omitempty"`
+}
+
+// certificateLister lists the certificates of one kind in a zone.
+type certificateLister struct {
+	kind string
+	list func(ctx context.Context, zoneID string) ([]expiringCertificate, error)
+}
+
+var certificateListers = []certificateLister{
+	{"custom", listCustomCertificateExpiry},
+	{"certificate_pack", listCertificatePackExpiry},
+	{"origin_ca", listOriginCAExpiry},
+}
+
+// parseWithin parses a number of days such as 30d, or a Go duration.
+func parseWithin(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --within %q: %w", s, err)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid --within %q: %w", s, err)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("--within must be positive, got %s", s)
+	}
+	return d, nil
+}
+
+// filterExpiring returns the certificates that expire before now+within,
+// soonest first, with the days they have left.
+func filterExpiring(certs []expiringCertificate, now time.Time, within time.Duration) []expiringCertificate {
+	deadline := now.Add(within)
+	var expiring []expiringCertificate
+	for _, cert := range certs {
+		if cert.ExpiresOn.IsZero() || cert.ExpiresOn.After(deadline) {
+			continue
+		}
+		cert.DaysLeft = int(math.Floor(cert.ExpiresOn.Sub(now).Hours() / 24))
+		expiring = append(expiring, cert)
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresOn.Before(expiring[j].ExpiresOn)
+	})
+	return expiring
+}
+
+func listCustomCertificateExpiry(ctx context.Context, zoneID string) ([]expiringCertificate, error) {
+	var certs []expiringCertificate
+	iter := client.CustomCertificates.ListAutoPaging(ctx, custom_certificates.CustomCertificateListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		cert := iter.Current()
+		certs = append(certs, expiringCertificate{
+			ID:        cert.ID,
+			Hosts:     cert.Hosts,
+			Status:    string(cert.Status),
+			ExpiresOn: cert.ExpiresOn,
+		})
+	}
+	return certs, iter.Err()
+}
+
+func listCertificatePackExpiry(ctx context.Context, zoneID string) ([]expiringCertificate, error) {
+	var certs []expiringCertificate
+	iter := client.SSL.CertificatePacks.ListAutoPaging(ctx, ssl.CertificatePackListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		p := iter.Current()
+		certs = append(certs, expiringCertificate{
+			ID:        p.ID,
+			Hosts:     p.Hosts,
+			Status:    string(p.Status),
+			ExpiresOn: packExpiry(p),
+		})
+	}
+	return certs, iter.Err()
+}
+
+func listOriginCAExpiry(ctx context.Context, zoneID string) ([]expiringCertificate, error) {
+	var certs []expiringCertificate
+	iter := client.OriginCACertificates.ListAutoPaging(ctx, origin_ca_certificates.OriginCACertificateListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		cert := iter.Current()
+		certs = append(certs, expiringCertificate{
+			ID:        cert.ID,
+			Hosts:     cert.Hostnames,
+			ExpiresOn: originCAExpiry(cert),
+		})
+	}
+	return certs, iter.Err()
+}
+
+// originCAExpiry returns the expiry of an Origin CA certificate. The API
+// returns it as text in no fixed format, so the certificate itself is used
+// when the text cannot be parsed.
+func originCAExpiry(cert origin_ca_certificates.OriginCACertificate) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05 -0700 MST", "2006-01-02 15:04:05 -0700"} {
+		if t, err := time.Parse(layout, cert.ExpiresOn); err == nil {
+			return t
+		}
+	}
+	if certs, err := parseCertificates([]byte(cert.Certificate)); err == nil {
+		return certs[0].NotAfter
+	}
+	return time.Time{}
+}
+
+// listZonesForExpiry returns all zones, or those of the --account account. An
+// account that is unknown or has no zones is an error, so that a typo does
+// not read as nothing expiring.
+func listZonesForExpiry(c *cobra.Command) ([]zones.Zone, error) {
+	params := zones.ZoneListParams{}
+	account, _ := c.Flags().GetString("account")
+	if account != "" {
+		accountID, err := getAccountID(c)
+		if err != nil {
+			return nil, err
+		}
+		params.Account = cloudflare.F(zones.ZoneListParamsAccount{ID: cloudflare.F(accountID)})
+	}
+
+	var list []zones.Zone
+	pager := client.Zones.ListAutoPaging(c.Context(), params)
+	for pager.Next() {
+		list = append(list, pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+		return nil, fmt.Errorf("Error listing zones: %w", err)
+	}
+	if account != "" && len(list) == 0 {
+		return nil, fmt.Errorf("account %q has no zones", account)
+	}
+	return list, nil
+}
+
+func sslExpiring(c *cobra.Command, args []string) error {
+	withinFlag, _ := c.Flags().GetString("within")
+	concurrency, _ := c.Flags().GetInt("concurrency")
+
+	within, err := parseWithin(withinFlag)
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	zoneList, err := listZonesForExpiry(c)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu     sync.Mutex
+		certs  []expiringCertificate
+		errs   []expiringCheckError
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, concurrency)
+		report = expiringReport{CheckedAt: time.Now().UTC(), Within: withinFlag, Zones: len(zoneList)}
+	)
+	for _, z := range zoneList {
+		for _, l := range certificateListers {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(z zones.Zone, l certificateLister) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				found, err := l.list(c.Context(), z.ID)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs = append(errs, expiringCheckError{Zone: z.Name, Kind: l.kind, Error: err.Error()})
+					return
+				}
+				for _, cert := range found {
+					cert.Zone = z.Name
+					cert.Kind = l.kind
+					certs = append(certs, cert)
+				}
+			}(z, l)
+		}
+	}
+	wg.Wait()
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Zone < errs[j].Zone || errs[i].Zone == errs[j].Zone && errs[i].Kind < errs[j].Kind
+	})
+	report.Certificates = filterExpiring(certs, report.CheckedAt, within)
+	report.Errors = errs
+	if report.Certificates == nil {
+		report.Certificates = []expiringCertificate{}
+	}
+
+	if jsonOutput(c) {
+		if err := writeJSON(report); err != nil {
+			return err
+		}
+	} else {
+		output := make([][]string, 0, len(report.Certificates))
+		for _, cert := range report.Certificates {
+			output = append(output, []string{
+				cert.Zone,
+				cert.Kind,
+				cert.ID,
+				strings.Join(cert.Hosts, ", "),
+				cert.Status,
+				cert.ExpiresOn.Format(time.RFC3339),
+				strconv.Itoa(cert.DaysLeft),
+			})
+		}
+		writeTable(output, "Zone", "Type", "ID", "Hosts", "Status", "Expires On", "Days Left")
+		for _, e := range report.Errors {
+			fmt.Fprintf(os.Stderr, "Error checking %s certificates of %s: %s\n", e.Kind, e.Zone, e.Error)
+		}
+	}
+
+	// The result is reported through the exit code, not as a usage error.
+	c.SilenceUsage = true
+	var failed string
+	if len(report.Errors) > 0 {
+		failed = fmt.Sprintf("%d of %d certificate checks failed", len(report.Errors), len(zoneList)*len(certificateListers))
+	}
+	if len(report.Certificates) > 0 {
+		msg := fmt.Sprintf("%d certificates expire within %s", len(report.Certificates), withinFlag)
+		if failed != "" {
+			msg += "; " + failed
+		}
+		return &exitError{code: 2, msg: msg}
+	}
+	if failed != "" {
+		return &exitError{code: 1, msg: failed}
+	}
+	return nil
+}
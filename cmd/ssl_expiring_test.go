@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6/origin_ca_certificates"
+)
+
+func TestParseWithin(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"72h": 72 * time.Hour,
+	}
+	for in, want := range tests {
+		got, err := parseWithin(in)
+		if err != nil || got != want {
+			t.Errorf("parseWithin(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "d", "thirty", "0d", "-5d"} {
+		if _, err := parseWithin(bad); err == nil {
+			t.Errorf("parseWithin(%q) = nil error; want error", bad)
+		}
+	}
+}
+
+func TestFilterExpiring(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	certs := []expiringCertificate{
+		{ID: "later", ExpiresOn: now.Add(60 * 24 * time.Hour)},
+		{ID: "soon", ExpiresOn: now.Add(10*24*time.Hour + time.Hour)},
+		{ID: "expired", ExpiresOn: now.Add(-36 * time.Hour)},
+		{ID: "unknown"},
+	}
+	got := filterExpiring(certs, now, 30*24*time.Hour)
+	if len(got) != 2 || got[0].ID != "expired" || got[1].ID != "soon" {
+		t.Fatalf("filterExpiring() = %+v", got)
+	}
+	if got[0].DaysLeft != -2 || got[1].DaysLeft != 10 {
+		t.Errorf("days left = %d, %d; want -2, 10", got[0].DaysLeft, got[1].DaysLeft)
+	}
+}
+
+func TestOriginCAExpiry(t *testing.T) {
+	want := time.Date(2027, 1, 1, 5, 20, 0, 0, time.UTC)
+	for _, s := range []string{"2027-01-01T05:20:00Z", "2027-01-01 05:20:00 +0000 UTC"} {
+		if got := originCAExpiry(origin_ca_certificates.OriginCACertificate{ExpiresOn: s}); !got.Equal(want) {
+			t.Errorf("originCAExpiry(%q) = %s; want %s", s, got, want)
+		}
+	}
+
+	root, _ := newTestCert(t, "Test Root", nil, nil)
+	cert := origin_ca_certificates.OriginCACertificate{
+		ExpiresOn:   "soon",
+		Certificate: string(encodeCertificates([]*x509.Certificate{root})),
+	}
+	if got := originCAExpiry(cert); !got.Equal(root.NotAfter) {
+		t.Errorf("originCAExpiry() from certificate = %s; want %s", got, root.NotAfter)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v6/ssl"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+func TestCertificatePackOrder(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		c := &cobra.Command{Use: "test"}
+		c.Flags().StringArray("host", nil, "")
+		c.Flags().String("ca", "lets_encrypt", "")
+		c.Flags().String("validation-method", "txt", "")
+		c.Flags().Int("validity", 90, "")
+		c.Flags().Bool("cloudflare-branding", false, "")
+		if err := c.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	params, err := certificatePackOrder(newCmd(), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hosts := params.Hosts.Value; len(hosts) != 2 || hosts[0] != "example.com" || hosts[1] != "*.example.com" {
+		t.Errorf("default hosts = %v", hosts)
+	}
+
+	bad := [][]string{
+		{"--host", "www.example.com"},
+		{"--host", "example.com", "--host", "www.example.org"},
+		{"--ca", "digicert"},
+		{"--validation-method", "dns"},
+		{"--validity", "60"},
+		{"--validation-method", "http"},
+	}
+	for _, args := range bad {
+		if _, err := certificatePackOrder(newCmd(args...), "example.com"); err == nil {
+			t.Errorf("certificatePackOrder(%v) = nil error; want error", args)
+		}
+	}
+
+	if _, err := certificatePackOrder(newCmd("--host", "example.com", "--host", "www.example.com", "--validation-method", "http"), "example.com"); err != nil {
+		t.Errorf("certificatePackOrder() with http validation = %v", err)
+	}
+}
+
+func TestVerificationRecord(t *testing.T) {
+	tests := map[string][2]string{
+		`{"certificate_status":"pending_validation","verification_info":{"record_name":"_acme-challenge.example.com","record_target":"abc"}}`: {"_acme-challenge.example.com", "abc"},
+		`{"certificate_status":"pending_validation","verification_info":{"http_url":"http://example.com/.well-known/x","http_body":"token"}}`: {"http://example.com/.well-known/x", "token"},
+		`{"certificate_status":"active"}`: {"", ""},
+	}
+	for raw, want := range tests {
+		var v ssl.Verification
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			t.Fatal(err)
+		}
+		name, target := verificationRecord(v)
+		if name != want[0] || target != want[1] {
+			t.Errorf("verificationRecord(%s) = %q, %q; want %q, %q", raw, name, target, want[0], want[1])
+		}
+	}
+}
+
+func TestLoadCertificateKeyPair(t *testing.T) {
+	dir := t.TempDir()
+	root, rootKey := newTestCert(t, "Test Root", nil, nil)
+	leaf, key := newTestCert(t, "www.example.com", root, rootKey)
+	_, otherKey := newTestCert(t, "other.example.com", root, rootKey)
+
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyPEM, err := encodePrivateKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := write("cert.pem", encodeCertificates([]*x509.Certificate{leaf, root}))
+	keyFile := write("key.pem", keyPEM)
+	otherKeyFile := write("other.key", otherKeyPEM)
+
+	_, _, got, err := loadCertificateKeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(leaf) {
+		t.Errorf("loadCertificateKeyPair() leaf = %s", got.Subject)
+	}
+
+	if _, _, _, err := loadCertificateKeyPair(certFile, otherKeyFile); err == nil {
+		t.Error("loadCertificateKeyPair() with mismatched key = nil error; want error")
+	}
+	if _, _, _, err := loadCertificateKeyPair(keyFile, keyFile); err == nil {
+		t.Error("loadCertificateKeyPair() with a key as certificate = nil error; want error")
+	}
+}
@@ -0,0 +1,446 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var transformCmd = &cobra.Command{
+	Use:   "transform",
+	Short: "Transform rules: URL rewrites and header modification",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+// transformPhase is a kind of transform rule, backed by the entry point
+// ruleset of its phase. Each one is exposed as list, create, update and delete
+// commands.
+type transformPhase struct {
+	use   string
+	short string
+	phase rulesets.Phase
+	// headerOps are the header operations allowed in the phase; none means the
+	// phase rewrites the URL instead.
+	headerOps []string
+}
+
+var transformPhases = []transformPhase{
+	{
+		use:   "url-rewrite",
+		short: "URL rewrite rules",
+		phase: rulesets.PhaseHTTPRequestTransform,
+	},
+	{
+		use:       "request-headers",
+		short:     "Request header modification rules",
+		phase:     rulesets.PhaseHTTPRequestLateTransform,
+		headerOps: []string{"set", "remove"},
+	},
+	{
+		use:       "response-headers",
+		short:     "Response header modification rules",
+		phase:     rulesets.PhaseHTTPResponseHeadersTransform,
+		headerOps: []string{"set", "add", "remove"},
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(transformCmd)
+	for _, t := range transformPhases {
+		transformCmd.AddCommand(newTransformCmd(t))
+	}
+}
+
+func newTransformCmd(t transformPhase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   t.use,
+		Short: t.short + " (" + string(t.phase) + " phase)",
+	}
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"l"},
+		Short:   "List the " + strings.ToLower(t.short) + " of a zone",
+		RunE: func(c *cobra.Command, args []string) error {
+			return transformList(c, t)
+		},
+	}
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a rule",
+		RunE: func(c *cobra.Command, args []string) error {
+			return transformCreate(c, t)
+		},
+	}
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a rule",
+		RunE: func(c *cobra.Command, args []string) error {
+			return transformUpdate(c, t)
+		},
+	}
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a rule",
+		RunE: func(c *cobra.Command, args []string) error {
+			return transformDelete(c, t)
+		},
+	}
+
+	listCmd.Flags().String("zone", "", "zone name")
+
+	addTransformRuleFlags(createCmd, t)
+	addRulePositionFlags(createCmd)
+
+	addTransformRuleFlags(updateCmd, t)
+	addRulePositionFlags(updateCmd)
+	updateCmd.Flags().String("id", "", "rule ID")
+
+	deleteCmd.Flags().String("zone", "", "zone name")
+	deleteCmd.Flags().String("id", "", "rule ID")
+
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(createCmd)
+	cmd.AddCommand(updateCmd)
+	cmd.AddCommand(deleteCmd)
+	return cmd
+}
+
+func addTransformRuleFlags(c *cobra.Command, t transformPhase) {
+	c.Flags().String("zone", "", "zone name")
+	c.Flags().String("expression", "", "filter expression of the requests to apply the rule to")
+	c.Flags().String("description", "", "rule description")
+	c.Flags().Bool("enabled", true, "whether the rule is enabled")
+
+	if len(t.headerOps) == 0 {
+		c.Flags().String("rewrite-path", "", "new URL path")
+		c.Flags().String("rewrite-path-expression", "", "expression building the new URL path")
+		c.Flags().String("rewrite-query", "", "new query string")
+		c.Flags().String("rewrite-query-expression", "", "expression building the new query string")
+		return
+	}
+	c.Flags().StringArray("set-header", nil, "set a header as NAME=VALUE (repeatable)")
+	c.Flags().StringArray("set-header-expression", nil, "set a header as NAME=EXPRESSION (repeatable)")
+	if slices.Contains(t.headerOps, "add") {
+		c.Flags().StringArray("add-header", nil, "add a header as NAME=VALUE, keeping existing ones (repeatable)")
+		c.Flags().StringArray("add-header-expression", nil, "add a header as NAME=EXPRESSION, keeping existing ones (repeatable)")
+	}
+	c.Flags().StringArray("remove-header", nil, "remove a header (repeatable)")
+}
+
+// validHeaderName reports whether name is a valid HTTP header field name.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > 127 || r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyTransformFlags merges the flags given on the command line into the
+// rewrite action parameters of a rule of the phase.
+func applyTransformFlags(c *cobra.Command, t transformPhase, params map[string]interface{}) error {
+	if len(t.headerOps) == 0 {
+		uri, _ := params["uri"].(map[string]interface{})
+		if uri == nil {
+			uri = map[string]interface{}{}
+		}
+		for _, part := range []string{"path", "query"} {
+			value, _ := c.Flags().GetString("rewrite-" + part)
+			expression, _ := c.Flags().GetString("rewrite-" + part + "-expression")
+			valueSet := c.Flags().Changed("rewrite-" + part)
+			switch {
+			case valueSet && expression != "":
+				return fmt.Errorf("only one of --rewrite-%s and --rewrite-%s-expression can be given", part, part)
+			case valueSet:
+				if part == "path" && !strings.HasPrefix(value, "/") {
+					return fmt.Errorf("invalid --rewrite-path %q: must start with /", value)
+				}
+				uri[part] = map[string]interface{}{"value": value}
+			case expression != "":
+				if err := validateExpression(expression); err != nil {
+					return fmt.Errorf("invalid --rewrite-%s-expression: %w", part, err)
+				}
+				uri[part] = map[string]interface{}{"expression": expression}
+			}
+		}
+		if len(uri) > 0 {
+			params["uri"] = uri
+		}
+		return nil
+	}
+
+	headers, _ := params["headers"].(map[string]interface{})
+	if headers == nil {
+		headers = map[string]interface{}{}
+	}
+	for _, op := range t.headerOps {
+		if op == "remove" {
+			names, _ := c.Flags().GetStringArray("remove-header")
+			for _, name := range names {
+				if !validHeaderName(name) {
+					return fmt.Errorf("invalid header name %q", name)
+				}
+				headers[name] = map[string]interface{}{"operation": "remove"}
+			}
+			continue
+		}
+		for _, kind := range []string{"value", "expression"} {
+			flag := op + "-header"
+			if kind == "expression" {
+				flag += "-expression"
+			}
+			values, _ := c.Flags().GetStringArray(flag)
+			for _, v := range values {
+				name, value, ok := strings.Cut(v, "=")
+				if !ok || !validHeaderName(name) {
+					return fmt.Errorf("invalid --%s %q: must be NAME=%s", flag, v, strings.ToUpper(kind))
+				}
+				if kind == "expression" {
+					if err := validateExpression(value); err != nil {
+						return fmt.Errorf("invalid --%s %q: %w", flag, v, err)
+					}
+				}
+				headers[name] = map[string]interface{}{"operation": op, kind: value}
+			}
+		}
+	}
+	if len(headers) > 0 {
+		params["headers"] = headers
+	}
+	return nil
+}
+
+// formatTransformChanges describes each rewrite of a rule, such as
+// `path = "/new"` or `set X-Foo = "bar"`. Expressions are shown unquoted.
+func formatTransformChanges(params map[string]interface{}) []string {
+	describe := func(v map[string]interface{}) string {
+		if e, ok := v["expression"].(string); ok {
+			return e
+		}
+		s, _ := v["value"].(string)
+		return strconv.Quote(s)
+	}
+
+	var changes []string
+	if uri, ok := params["uri"].(map[string]interface{}); ok {
+		for _, part := range []string{"path", "query"} {
+			if v, ok := uri[part].(map[string]interface{}); ok {
+				changes = append(changes, part+" = "+describe(v))
+			}
+		}
+	}
+	if headers, ok := params["headers"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			h, _ := headers[name].(map[string]interface{})
+			op, _ := h["operation"].(string)
+			if op == "remove" {
+				changes = append(changes, "remove "+name)
+				continue
+			}
+			changes = append(changes, op+" "+name+" = "+describe(h))
+		}
+	}
+	return changes
+}
+
+func formatTransformRule(position int, r rulesets.RulesetGetResponseRule) []string {
+	params := map[string]interface{}{}
+	if !r.JSON.ActionParameters.IsNull() {
+		_ = json.Unmarshal([]byte(r.JSON.ActionParameters.Raw()), &params)
+	}
+	return []string{
+		strconv.Itoa(position),
+		r.ID,
+		formatBool(r.Enabled),
+		r.Description,
+		r.Expression,
+		strings.Join(formatTransformChanges(params), "; "),
+	}
+}
+
+func writeTransformRules(c *cobra.Command, rs *rulesets.RulesetGetResponse) error {
+	if jsonOutput(c) {
+		if rs == nil {
+			return writeJSON([]interface{}{})
+		}
+		return writeJSON(json.RawMessage(rs.JSON.Rules.Raw()))
+	}
+
+	output := make([][]string, 0)
+	if rs != nil {
+		for i, r := range rs.Rules {
+			output = append(output, formatTransformRule(i+1, r))
+		}
+	}
+	writeTable(output, "Position", "ID", "Enabled", "Description", "Expression", "Changes")
+	return nil
+}
+
+func transformList(c *cobra.Command, t transformPhase) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, t.phase)
+	if err != nil {
+		return fmt.Errorf("Error listing %s: %w", strings.ToLower(t.short), err)
+	}
+	return writeTransformRules(c, rs)
+}
+
+func transformCreate(c *cobra.Command, t transformPhase) error {
+	if err := checkFlags(c, "zone", "expression"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	expression, _ := c.Flags().GetString("expression")
+	description, _ := c.Flags().GetString("description")
+	enabled, _ := c.Flags().GetBool("enabled")
+
+	if err := validateExpression(expression); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	params := map[string]interface{}{}
+	if err := applyTransformFlags(c, t, params); err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		if len(t.headerOps) == 0 {
+			return errors.New("nothing to rewrite: use --rewrite-path or --rewrite-query, or their -expression variants")
+		}
+		return errors.New("no header changes: use --set-header, --remove-header or their variants")
+	}
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rule := rulesetRule{
+		Action:           string(rulesets.RulesetGetResponseRulesActionRewrite),
+		ActionParameters: params,
+		Description:      description,
+		Expression:       expression,
+		Enabled:          enabled,
+	}
+	rs, err := addPhaseRule(c, "", zoneID, t.phase, rule, position)
+	if err != nil {
+		return fmt.Errorf("Error creating rule: %w", err)
+	}
+	return writeTransformRules(c, rs)
+}
+
+func transformUpdate(c *cobra.Command, t transformPhase) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	position, err := getRulePosition(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, t.phase)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no %s", zoneName, strings.ToLower(t.short))
+	}
+	existing, ok := findRulesetRule(rs, id)
+	if !ok {
+		return fmt.Errorf("rule %q not found", id)
+	}
+
+	rule := rulesetRuleFromExisting(existing)
+	params := map[string]interface{}{}
+	if !existing.JSON.ActionParameters.IsNull() {
+		if err := json.Unmarshal([]byte(existing.JSON.ActionParameters.Raw()), &params); err != nil {
+			return err
+		}
+	}
+	if err := applyTransformFlags(c, t, params); err != nil {
+		return err
+	}
+	rule.ActionParameters = params
+
+	if c.Flags().Changed("expression") {
+		rule.Expression, _ = c.Flags().GetString("expression")
+		if err := validateExpression(rule.Expression); err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+	}
+	if c.Flags().Changed("description") {
+		rule.Description, _ = c.Flags().GetString("description")
+	}
+	if c.Flags().Changed("enabled") {
+		rule.Enabled, _ = c.Flags().GetBool("enabled")
+	}
+
+	rs, err = editRulesetRule(c, "", zoneID, rs.ID, id, rule, position)
+	if err != nil {
+		return fmt.Errorf("Error updating rule: %w", err)
+	}
+	return writeTransformRules(c, rs)
+}
+
+func transformDelete(c *cobra.Command, t transformPhase) error {
+	if err := checkFlags(c, "zone", "id"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("id")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	rs, err := getPhaseEntrypoint(c, "", zoneID, t.phase)
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return fmt.Errorf("zone %q has no %s", zoneName, strings.ToLower(t.short))
+	}
+
+	rs, err = deleteRulesetRule(c, "", zoneID, rs.ID, id)
+	if err != nil {
+		return fmt.Errorf("Error deleting rule: %w", err)
+	}
+	return writeTransformRules(c, rs)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newTransformTestCmd(t *testing.T, p transformPhase, args ...string) *cobra.Command {
+	t.Helper()
+	c := &cobra.Command{Use: "test"}
+	addTransformRuleFlags(c, p)
+	if err := c.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestApplyTransformFlags(t *testing.T) {
+	urlRewrite, requestHeaders, responseHeaders := transformPhases[0], transformPhases[1], transformPhases[2]
+
+	params := map[string]interface{}{}
+	c := newTransformTestCmd(t, urlRewrite, "--rewrite-path-expression", `regex_replace(http.request.uri.path, "^/old/", "/new/")`, "--rewrite-query", "")
+	if err := applyTransformFlags(c, urlRewrite, params); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"uri": map[string]interface{}{
+		"path":  map[string]interface{}{"expression": `regex_replace(http.request.uri.path, "^/old/", "/new/")`},
+		"query": map[string]interface{}{"value": ""},
+	}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("applyTransformFlags() = %v; want %v", params, want)
+	}
+
+	// Updates keep the headers that are not mentioned.
+	params = map[string]interface{}{"headers": map[string]interface{}{
+		"X-Keep": map[string]interface{}{"operation": "set", "value": "1"},
+	}}
+	c = newTransformTestCmd(t, responseHeaders, "--set-header", "X-Frame-Options=DENY", "--add-header-expression", "X-Colo=cf.colo.name", "--remove-header", "Server")
+	if err := applyTransformFlags(c, responseHeaders, params); err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]interface{}{"headers": map[string]interface{}{
+		"X-Keep":          map[string]interface{}{"operation": "set", "value": "1"},
+		"X-Frame-Options": map[string]interface{}{"operation": "set", "value": "DENY"},
+		"X-Colo":          map[string]interface{}{"operation": "add", "expression": "cf.colo.name"},
+		"Server":          map[string]interface{}{"operation": "remove"},
+	}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("applyTransformFlags() = %v; want %v", params, want)
+	}
+
+	for _, tt := range []struct {
+		phase transformPhase
+		args  []string
+	}{
+		{urlRewrite, []string{"--rewrite-path", "new"}},
+		{urlRewrite, []string{"--rewrite-path", "/new", "--rewrite-path-expression", "x"}},
+		{requestHeaders, []string{"--set-header", "X-Foo"}},
+		{requestHeaders, []string{"--set-header", "Bad Name=1"}},
+		{requestHeaders, []string{"--set-header-expression", `X-Foo=concat("a"`}},
+	} {
+		c := newTransformTestCmd(t, tt.phase, tt.args...)
+		if err := applyTransformFlags(c, tt.phase, map[string]interface{}{}); err == nil {
+			t.Errorf("applyTransformFlags(%v) = nil error; want error", tt.args)
+		}
+	}
+}
+
+func TestFormatTransformChanges(t *testing.T) {
+	params := map[string]interface{}{
+		"uri": map[string]interface{}{"path": map[string]interface{}{"value": "/new"}},
+		"headers": map[string]interface{}{
+			"X-B": map[string]interface{}{"operation": "remove"},
+			"X-A": map[string]interface{}{"operation": "set", "expression": "cf.colo.name"},
+		},
+	}
+	want := []string{`path = "/new"`, "set X-A = cf.colo.name", "remove X-B"}
+	if got := formatTransformChanges(params); !reflect.DeepEqual(got, want) {
+		t.Errorf("formatTransformChanges() = %q; want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/cloudflare/cloudflare-go/v6"
@@ -54,20 +55,34 @@ var userAgentDeleteCmd = &cobra.Command{
 	},
 }
 
+var userAgentImportCmd = &cobra.Command{
+	Use:     "import",
+	Aliases: []string{"i"},
+	Short:   "Create User-Agent blocks from a file with one User-Agent per line",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return userAgentImport(cmd)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(userAgentCmd)
 	userAgentCmd.AddCommand(userAgentListCmd)
 	userAgentCmd.AddCommand(userAgentCreateCmd)
 	userAgentCmd.AddCommand(userAgentUpdateCmd)
 	userAgentCmd.AddCommand(userAgentDeleteCmd)
+	userAgentCmd.AddCommand(userAgentImportCmd)
 
 	// List flags
 	userAgentListCmd.Flags().String("zone", "", "zone name")
-	userAgentListCmd.Flags().Int("page", 0, "result page to return")
+	userAgentListCmd.Flags().Int("page", 0, "result page to return (implies --all=false)")
+	userAgentListCmd.Flags().Bool("all", true, "fetch all pages")
+	userAgentListCmd.Flags().String("mode", "", "only rules with this mode")
+	userAgentListCmd.Flags().String("description", "", "only rules whose description contains this text")
+	userAgentListCmd.Flags().String("ua", "", "only rules whose User-Agent contains this text")
 
 	// Create flags
 	userAgentCreateCmd.Flags().String("zone", "", "zone name")
-	userAgentCreateCmd.Flags().String("mode", "", "the blocking mode: block, challenge, js_challenge, whitelist")
+	userAgentCreateCmd.Flags().String("mode", "", "the blocking mode: block, challenge, js_challenge, managed_challenge, whitelist")
 	userAgentCreateCmd.Flags().String("value", "", "the exact User-Agent to block")
 	userAgentCreateCmd.Flags().Bool("paused", false, "whether the rule should be paused (default: false)")
 	userAgentCreateCmd.Flags().String("description", "", "a description for the rule")
@@ -75,7 +90,7 @@ func init() {
 	// Update flags
 	userAgentUpdateCmd.Flags().String("zone", "", "zone name")
 	userAgentUpdateCmd.Flags().String("id", "", "User-Agent blocking rule ID")
-	userAgentUpdateCmd.Flags().String("mode", "", "the blocking mode: block, challenge, js_challenge, whitelist")
+	userAgentUpdateCmd.Flags().String("mode", "", "the blocking mode: block, challenge, js_challenge, managed_challenge, whitelist")
 	userAgentUpdateCmd.Flags().String("value", "", "the exact User-Agent to block")
 	userAgentUpdateCmd.Flags().Bool("paused", false, "whether the rule should be paused (default: false)")
 	userAgentUpdateCmd.Flags().String("description", "", "a description for the rule")
@@ -83,6 +98,13 @@ func init() {
 	// Delete flags
 	userAgentDeleteCmd.Flags().String("zone", "", "zone name")
 	userAgentDeleteCmd.Flags().String("id", "", "User-Agent blocking rule ID")
+
+	// Import flags
+	userAgentImportCmd.Flags().String("zone", "", "zone name")
+	userAgentImportCmd.Flags().String("file", "", "file with one User-Agent per line; blank lines and lines starting with # are skipped")
+	userAgentImportCmd.Flags().String("mode", "", "the blocking mode: block, challenge, js_challenge, managed_challenge, whitelist")
+	userAgentImportCmd.Flags().Bool("paused", false, "whether the rules should be paused (default: false)")
+	userAgentImportCmd.Flags().String("description", "", "a description for the rules")
 }
 
 func formatUserAgentRule(id, description, mode, value string, paused bool) []string {
@@ -95,12 +117,24 @@ func formatUserAgentRule(id, description, mode, value string, paused bool) []str
 	}
 }
 
+// checkUserAgentMode checks the mode of a new rule before anything is sent.
+func checkUserAgentMode(mode string) error {
+	if !firewall.UARuleNewParamsMode(mode).IsKnown() {
+		return fmt.Errorf("invalid mode %q: must be block, challenge, js_challenge, managed_challenge or whitelist", mode)
+	}
+	return nil
+}
+
 func userAgentList(c *cobra.Command) error {
 	if err := checkFlags(c, "zone"); err != nil {
 		return err
 	}
 	zoneName, _ := c.Flags().GetString("zone")
 	page, _ := c.Flags().GetInt("page")
+	all, _ := c.Flags().GetBool("all")
+	mode, _ := c.Flags().GetString("mode")
+	description, _ := c.Flags().GetString("description")
+	ua, _ := c.Flags().GetString("ua")
 
 	zoneID, err := getZoneIDByName(c, zoneName)
 	if err != nil {
@@ -110,24 +144,54 @@ func userAgentList(c *cobra.Command) error {
 	params := firewall.UARuleListParams{
 		ZoneID: cloudflare.F(zoneID),
 	}
+	if description != "" {
+		params.Description = cloudflare.F(description)
+	}
+	if ua != "" {
+		params.UserAgent = cloudflare.F(ua)
+	}
 
-	if page > 0 {
-		params.Page = cloudflare.F(float64(page))
+	var rules []firewall.UARuleListResponse
+
+	// An explicit --page keeps the legacy behaviour of returning that page only.
+	if c.Flags().Changed("page") || !all {
+		if page > 0 {
+			params.Page = cloudflare.F(float64(page))
+		} else {
+			params.Page = cloudflare.F(1.0)
+		}
+		resp, err := client.Firewall.UARules.List(c.Context(), params)
+		if err != nil {
+			return fmt.Errorf("Error listing User-Agent block rules: %w", err)
+		}
+		rules = resp.Result
 	} else {
-		// Legacy behavior implicitly listed page 1 if not specified.
-		// To match strict legacy behavior (which didn't list all by default), we default to page 1.
-		params.Page = cloudflare.F(1.0)
+		pager := client.Firewall.UARules.ListAutoPaging(c.Context(), params)
+		for pager.Next() {
+			rules = append(rules, pager.Current())
+		}
+		if err := pager.Err(); err != nil {
+			return fmt.Errorf("Error listing User-Agent block rules: %w", err)
+		}
 	}
 
-	// We use List, not ListAutoPaging, because we want to support specific page requests
-	// and default to page 1 if not specified (matching legacy).
-	resp, err := client.Firewall.UARules.List(c.Context(), params)
-	if err != nil {
-		return fmt.Errorf("Error listing User-Agent block rules: %w", err)
+	// The API cannot filter on mode.
+	if mode != "" {
+		filtered := rules[:0]
+		for _, rule := range rules {
+			if string(rule.Mode) == mode {
+				filtered = append(filtered, rule)
+			}
+		}
+		rules = filtered
 	}
 
-	output := make([][]string, 0, len(resp.Result))
-	for _, rule := range resp.Result {
+	if jsonOutput(c) {
+		return writeJSON(rules)
+	}
+
+	output := make([][]string, 0, len(rules))
+	for _, rule := range rules {
 		output = append(output, formatUserAgentRule(
 			rule.ID,
 			rule.Description,
@@ -152,6 +216,10 @@ func userAgentCreate(c *cobra.Command) error {
 	paused, _ := c.Flags().GetBool("paused")
 	description, _ := c.Flags().GetString("description")
 
+	if err := checkUserAgentMode(mode); err != nil {
+		return err
+	}
+
 	zoneID, err := getZoneIDByName(c, zoneName)
 	if err != nil {
 		return err
@@ -188,23 +256,52 @@ func userAgentCreate(c *cobra.Command) error {
 }
 
 func userAgentUpdate(c *cobra.Command) error {
-	// Legacy required flags: zone, id, mode, value
-	if err := checkFlags(c, "zone", "id", "mode", "value"); err != nil {
+	if err := checkFlags(c, "zone", "id"); err != nil {
 		return err
 	}
 
 	zoneName, _ := c.Flags().GetString("zone")
 	id, _ := c.Flags().GetString("id")
-	mode, _ := c.Flags().GetString("mode")
-	value, _ := c.Flags().GetString("value")
-	paused, _ := c.Flags().GetBool("paused")
-	description, _ := c.Flags().GetString("description")
+
+	if c.Flags().Changed("mode") {
+		mode, _ := c.Flags().GetString("mode")
+		if !firewall.UARuleUpdateParamsMode(mode).IsKnown() {
+			return fmt.Errorf("invalid mode %q: must be block, challenge, js_challenge, managed_challenge or whitelist", mode)
+		}
+	}
 
 	zoneID, err := getZoneIDByName(c, zoneName)
 	if err != nil {
 		return err
 	}
 
+	// The API replaces the whole rule, so start from the current one and only
+	// change the fields given on the command line.
+	existing, err := client.Firewall.UARules.Get(c.Context(), id, firewall.UARuleGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error fetching User-Agent block rule: %w", err)
+	}
+
+	mode := string(existing.Mode)
+	value := existing.Configuration.Value
+	paused := existing.Paused
+	description := existing.Description
+
+	if c.Flags().Changed("mode") {
+		mode, _ = c.Flags().GetString("mode")
+	}
+	if c.Flags().Changed("value") {
+		value, _ = c.Flags().GetString("value")
+	}
+	if c.Flags().Changed("paused") {
+		paused, _ = c.Flags().GetBool("paused")
+	}
+	if c.Flags().Changed("description") {
+		description, _ = c.Flags().GetString("description")
+	}
+
 	params := firewall.UARuleUpdateParams{
 		ZoneID: cloudflare.F(zoneID),
 		Configuration: cloudflare.F[firewall.UARuleUpdateParamsConfigurationUnion](firewall.UARuleUpdateParamsConfiguration{
@@ -272,3 +369,87 @@ func userAgentDelete(c *cobra.Command) error {
 	writeTable(output, "ID", "Description", "Mode", "Value", "Paused")
 	return nil
 }
+
+func userAgentImport(c *cobra.Command) error {
+	if err := checkFlags(c, "zone", "file", "mode"); err != nil {
+		return err
+	}
+
+	zoneName, _ := c.Flags().GetString("zone")
+	file, _ := c.Flags().GetString("file")
+	mode, _ := c.Flags().GetString("mode")
+	paused, _ := c.Flags().GetBool("paused")
+	description, _ := c.Flags().GetString("description")
+
+	if err := checkUserAgentMode(mode); err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	uas, err := readLines(f)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	// Skip User-Agents that already have a rule.
+	existing := make(map[string]bool)
+	pager := client.Firewall.UARules.ListAutoPaging(c.Context(), firewall.UARuleListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for pager.Next() {
+		existing[pager.Current().Configuration.Value] = true
+	}
+	if err := pager.Err(); err != nil {
+		return fmt.Errorf("Error listing User-Agent block rules: %w", err)
+	}
+
+	output := make([][]string, 0, len(uas))
+	failed := 0
+	for _, ua := range uas {
+		if existing[ua] {
+			fmt.Fprintf(os.Stderr, "Skipping existing User-Agent block rule for %q\n", ua)
+			continue
+		}
+
+		params := firewall.UARuleNewParams{
+			ZoneID: cloudflare.F(zoneID),
+			Configuration: cloudflare.F(firewall.UARuleNewParamsConfiguration{
+				Target: cloudflare.F(firewall.UARuleNewParamsConfigurationTargetUA),
+				Value:  cloudflare.F(ua),
+			}),
+			Mode:        cloudflare.F(firewall.UARuleNewParamsMode(mode)),
+			Paused:      cloudflare.F(paused),
+			Description: cloudflare.F(description),
+		}
+
+		resp, err := client.Firewall.UARules.New(c.Context(), params)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating User-Agent block rule for %q: %v\n", ua, err)
+			failed++
+			continue
+		}
+		output = append(output, formatUserAgentRule(
+			resp.ID,
+			resp.Description,
+			string(resp.Mode),
+			resp.Configuration.Value,
+			resp.Paused,
+		))
+	}
+
+	writeTable(output, "ID", "Description", "Mode", "Value", "Paused")
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d User-Agent block rules could not be created", failed, len(uas))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import "testing"
+
+func TestCheckUserAgentMode(t *testing.T) {
+	for _, mode := range []string{"block", "challenge", "js_challenge", "managed_challenge", "whitelist"} {
+		if err := checkUserAgentMode(mode); err != nil {
+			t.Errorf("checkUserAgentMode(%q) = %v; want nil", mode, err)
+		}
+	}
+	for _, mode := range []string{"", "Block", "allow"} {
+		if err := checkUserAgentMode(mode); err == nil {
+			t.Errorf("checkUserAgentMode(%q) = nil; want error", mode)
+		}
+	}
+}
@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/accounts"
 	"github.com/cloudflare/cloudflare-go/v6/option"
 	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/goccy/go-json"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
@@ -41,6 +48,71 @@ func writeTable(data [][]string, cols ...string) {
 	table.Render()
 }
 
+// jsonOutput reports whether the global --json flag was given
+func jsonOutput(c *cobra.Command) bool {
+	b, _ := c.Flags().GetBool("json")
+	return b
+}
+
+// writeJSON prints v as indented JSON, the --json counterpart of writeTable
+func writeJSON(v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// isNotFound reports whether err is an API error with a 404 status
+func isNotFound(err error) bool {
+	var apiErr *cloudflare.Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// diffLines returns a line diff turning a into b. Unchanged lines are prefixed
+// with two spaces, removed lines with "- " and added lines with "+ ".
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "- "+a[i])
+			i++
+		default:
+			out = append(out, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+ "+b[j])
+	}
+	return out
+}
+
 // ensureClient can be used by commands to make sure client is ready
 func ensureClient() error {
 	if client == nil {
@@ -71,6 +143,31 @@ func getZoneIDByName(c *cobra.Command, zoneName string) (string, error) {
 	return res.Result[0].ID, nil
 }
 
+// getAccountID returns the ID of the account given by the required --account
+// flag, as a name or an ID. A name that matches no account is an error.
+func getAccountID(c *cobra.Command) (string, error) {
+	if err := checkFlags(c, "account"); err != nil {
+		return "", err
+	}
+	account, _ := c.Flags().GetString("account")
+	if isAccountID(account) {
+		return account, nil
+	}
+
+	iter := client.Accounts.ListAutoPaging(c.Context(), accounts.AccountListParams{
+		Name: cloudflare.F(account),
+	})
+	for iter.Next() {
+		if acc := iter.Current(); acc.Name == account {
+			return acc.ID, nil
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return "", fmt.Errorf("Error listing accounts: %w", err)
+	}
+	return "", fmt.Errorf("account %q not found", account)
+}
+
 func checkFlags(c *cobra.Command, flags ...string) error {
 	for _, flag := range flags {
 		val, err := c.Flags().GetString(flag)
@@ -83,3 +180,20 @@ func checkFlags(c *cobra.Command, flags ...string) error {
 	}
 	return nil
 }
+
+// readLines reads the non-empty lines of r, skipping duplicates and lines
+// starting with "#".
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
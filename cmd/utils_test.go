@@ -1,6 +1,10 @@
 package cmd
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestFormatBool(t *testing.T) {
 	if got := formatBool(true); got != "true" {
@@ -10,3 +14,34 @@ func TestFormatBool(t *testing.T) {
 		t.Errorf("formatBool(false) = %q; want \"false\"", got)
 	}
 }
+
+func TestDiffLines(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "c", "d", "e"}
+	want := []string{"  a", "- b", "  c", "  d", "+ e"}
+	if got := diffLines(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffLines = %q; want %q", got, want)
+	}
+
+	if got := diffLines(nil, []string{"x"}); !reflect.DeepEqual(got, []string{"+ x"}) {
+		t.Errorf("diffLines(nil, [x]) = %q", got)
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	input := `# bad bots
+BadBot/1.0
+
+  EvilCrawler/2.1 (+http://example.com)
+BadBot/1.0
+#NotThisOne
+`
+	got, err := readLines(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readLines failed: %v", err)
+	}
+	want := []string{"BadBot/1.0", "EvilCrawler/2.1 (+http://example.com)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readLines = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/rulesets"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var wafCmd = &cobra.Command{
+	Use:   "waf",
+	Short: "Web Application Firewall",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var wafManagedCmd = &cobra.Command{
+	Use:   "managed",
+	Short: "Managed rulesets deployed to a zone",
+}
+
+var wafManagedListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List managed rulesets deployed to a zone",
+	RunE:    wafManagedList,
+}
+
+var wafManagedShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the rules of a managed ruleset and their overrides",
+	RunE:  wafManagedShow,
+}
+
+var wafManagedOverrideCmd = &cobra.Command{
+	Use:   "override",
+	Short: "Add or remove an override for a managed rule or tag",
+	Long: `Add or remove an override for a managed rule or tag. The fields given on
+the command line are merged into an existing override of the rule or tag;
+its other fields are kept. Use --remove to drop the override.`,
+	RunE: wafManagedOverride,
+}
+
+func init() {
+	rootCmd.AddCommand(wafCmd)
+	wafCmd.AddCommand(wafManagedCmd)
+	wafManagedCmd.AddCommand(wafManagedListCmd)
+	wafManagedCmd.AddCommand(wafManagedShowCmd)
+	wafManagedCmd.AddCommand(wafManagedOverrideCmd)
+
+	wafManagedListCmd.Flags().String("zone", "", "zone name")
+
+	wafManagedShowCmd.Flags().String("zone", "", "zone name")
+	wafManagedShowCmd.Flags().String("ruleset", "", "managed ruleset ID or name")
+	wafManagedShowCmd.Flags().String("tag", "", "only rules with this tag")
+
+	wafManagedOverrideCmd.Flags().String("zone", "", "zone name")
+	wafManagedOverrideCmd.Flags().String("ruleset", "", "managed ruleset ID or name")
+	wafManagedOverrideCmd.Flags().String("rule", "", "ID of the managed rule to override")
+	wafManagedOverrideCmd.Flags().String("tag", "", "tag of the managed rules to override")
+	wafManagedOverrideCmd.Flags().String("action", "", "action to use instead of the default one")
+	wafManagedOverrideCmd.Flags().Bool("enabled", true, "whether the rule or tag is enabled")
+	wafManagedOverrideCmd.Flags().Int("score-threshold", 0, "anomaly score threshold, e.g. for the OWASP score rule")
+	wafManagedOverrideCmd.Flags().String("sensitivity-level", "", "sensitivity level: default, medium, low or eoff")
+	wafManagedOverrideCmd.Flags().Bool("remove", false, "remove the override instead of setting it")
+	wafManagedOverrideCmd.Flags().Bool("dry-run", false, "only show the change")
+}
+
+// managedDeployment is an execute rule of the managed phase entry point
+// together with the managed ruleset it runs.
+type managedDeployment struct {
+	Rule      rulesets.RulesetGetResponseRule
+	RulesetID string
+	Overrides map[string]interface{}
+}
+
+// getManagedDeployments returns the entry point ruleset of the managed WAF
+// phase and the managed rulesets it executes.
+func getManagedDeployments(c *cobra.Command, zoneID string) (*rulesets.RulesetGetResponse, []managedDeployment, error) {
+	rs, err := getPhaseEntrypoint(c, "", zoneID, rulesets.PhaseHTTPRequestFirewallManaged)
+	if err != nil || rs == nil {
+		return rs, nil, err
+	}
+
+	var deployments []managedDeployment
+	for _, r := range rs.Rules {
+		if r.Action != rulesets.RulesetGetResponseRulesActionExecute {
+			continue
+		}
+		var params struct {
+			ID        string                 `json:"id"`
+			Overrides map[string]interface{} `json:"overrides"`
+		}
+		if err := json.Unmarshal([]byte(r.JSON.ActionParameters.Raw()), &params); err != nil {
+			return nil, nil, err
+		}
+		deployments = append(deployments, managedDeployment{
+			Rule:      r,
+			RulesetID: params.ID,
+			Overrides: params.Overrides,
+		})
+	}
+	return rs, deployments, nil
+}
+
+// getManagedRulesetNames maps the IDs of the managed rulesets available to a
+// zone to their names.
+func getManagedRulesetNames(c *cobra.Command, zoneID string) (map[string]string, error) {
+	names := make(map[string]string)
+	pager := client.Rulesets.ListAutoPaging(c.Context(), rulesets.RulesetListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for pager.Next() {
+		rs := pager.Current()
+		if rs.Kind == rulesets.KindManaged {
+			names[rs.ID] = rs.Name
+		}
+	}
+	return names, pager.Err()
+}
+
+// findManagedDeployment finds the deployment of the --ruleset flag, given as
+// an ID or a case-insensitive name.
+func findManagedDeployment(c *cobra.Command, zoneID string) (*rulesets.RulesetGetResponse, *managedDeployment, error) {
+	ruleset, _ := c.Flags().GetString("ruleset")
+
+	entrypoint, deployments, err := getManagedDeployments(c, zoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+	names, err := getManagedRulesetNames(c, zoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, d := range deployments {
+		if d.RulesetID == ruleset || strings.EqualFold(names[d.RulesetID], ruleset) {
+			return entrypoint, &deployments[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("managed ruleset %q is not deployed to this zone", ruleset)
+}
+
+// overrideKey returns the key and value identifying the override requested
+// by the --rule or --tag flag.
+func overrideKey(c *cobra.Command) (string, string, string, error) {
+	rule, _ := c.Flags().GetString("rule")
+	tag, _ := c.Flags().GetString("tag")
+	switch {
+	case rule != "" && tag != "":
+		return "", "", "", errors.New("only one of --rule and --tag can be given")
+	case rule != "":
+		return "rules", "id", rule, nil
+	case tag != "":
+		return "categories", "category", tag, nil
+	}
+	return "", "", "", errors.New("error: one of --rule or --tag is required")
+}
+
+// setOverride merges entry into the override in overrides[list] whose key
+// field equals value, keeping its other fields and its place in the list, or
+// adds entry if there is no such override. A nil entry removes the override.
+func setOverride(overrides map[string]interface{}, list, key, value string, entry map[string]interface{}) {
+	existing, _ := overrides[list].([]interface{})
+	updated := make([]interface{}, 0, len(existing)+1)
+	found := false
+	for _, o := range existing {
+		if m, ok := o.(map[string]interface{}); ok && m[key] == value {
+			if entry == nil {
+				continue
+			}
+			for k, v := range entry {
+				m[k] = v
+			}
+			found = true
+		}
+		updated = append(updated, o)
+	}
+	if entry != nil && !found {
+		updated = append(updated, entry)
+	}
+
+	if len(updated) == 0 {
+		delete(overrides, list)
+	} else {
+		overrides[list] = updated
+	}
+}
+
+// buildOverride creates the override entry from the command line flags.
+func buildOverride(c *cobra.Command, key, value string) map[string]interface{} {
+	entry := map[string]interface{}{key: value}
+	if c.Flags().Changed("action") {
+		entry["action"], _ = c.Flags().GetString("action")
+	}
+	if c.Flags().Changed("enabled") {
+		entry["enabled"], _ = c.Flags().GetBool("enabled")
+	}
+	if c.Flags().Changed("score-threshold") {
+		entry["score_threshold"], _ = c.Flags().GetInt("score-threshold")
+	}
+	if c.Flags().Changed("sensitivity-level") {
+		entry["sensitivity_level"], _ = c.Flags().GetString("sensitivity-level")
+	}
+	return entry
+}
+
+func overrideLines(overrides map[string]interface{}) []string {
+	if len(overrides) == 0 {
+		return nil
+	}
+	b, _ := json.MarshalIndent(overrides, "", "  ")
+	return strings.Split(string(b), "\n")
+}
+
+func formatOverride(o map[string]interface{}) string {
+	var parts []string
+	for _, k := range []string{"action", "enabled", "score_threshold", "sensitivity_level"} {
+		if v, ok := o[k]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func wafManagedList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	_, deployments, err := getManagedDeployments(c, zoneID)
+	if err != nil {
+		return err
+	}
+	names, err := getManagedRulesetNames(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput(c) {
+		rules := make([]json.RawMessage, 0, len(deployments))
+		for _, d := range deployments {
+			rules = append(rules, json.RawMessage(d.Rule.JSON.RawJSON()))
+		}
+		return writeJSON(rules)
+	}
+
+	output := make([][]string, 0, len(deployments))
+	for _, d := range deployments {
+		rules, _ := d.Overrides["rules"].([]interface{})
+		categories, _ := d.Overrides["categories"].([]interface{})
+		output = append(output, []string{
+			d.RulesetID,
+			names[d.RulesetID],
+			d.Rule.ID,
+			formatBool(d.Rule.Enabled),
+			fmt.Sprintf("%d rules, %d tags", len(rules), len(categories)),
+			d.Rule.Expression,
+		})
+	}
+	writeTable(output, "Ruleset ID", "Name", "Rule ID", "Enabled", "Overrides", "Expression")
+	return nil
+}
+
+func wafManagedShow(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "ruleset"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	tag, _ := c.Flags().GetString("tag")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	_, deployment, err := findManagedDeployment(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	managed, err := client.Rulesets.Get(c.Context(), deployment.RulesetID, rulesets.RulesetGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(managed.JSON.RawJSON()))
+	}
+
+	ruleOverrides := make(map[string]map[string]interface{})
+	if list, ok := deployment.Overrides["rules"].([]interface{}); ok {
+		for _, o := range list {
+			if m, ok := o.(map[string]interface{}); ok {
+				if id, ok := m["id"].(string); ok {
+					ruleOverrides[id] = m
+				}
+			}
+		}
+	}
+
+	output := make([][]string, 0, len(managed.Rules))
+	for _, r := range managed.Rules {
+		var tags []string
+		if !r.JSON.Categories.IsNull() {
+			_ = json.Unmarshal([]byte(r.JSON.Categories.Raw()), &tags)
+		}
+		if tag != "" {
+			found := false
+			for _, t := range tags {
+				if t == tag {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		output = append(output, []string{
+			r.ID,
+			r.Description,
+			string(r.Action),
+			formatBool(r.Enabled),
+			strings.Join(tags, ", "),
+			formatOverride(ruleOverrides[r.ID]),
+		})
+	}
+	writeTable(output, "ID", "Description", "Action", "Enabled", "Tags", "Override")
+	return nil
+}
+
+func wafManagedOverride(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "ruleset"); err != nil {
+		return err
+	}
+	list, key, value, err := overrideKey(c)
+	if err != nil {
+		return err
+	}
+	remove, _ := c.Flags().GetBool("remove")
+	dryRun, _ := c.Flags().GetBool("dry-run")
+
+	var entry map[string]interface{}
+	if !remove {
+		entry = buildOverride(c, key, value)
+		if len(entry) == 1 {
+			return errors.New("error: nothing to override, give --action, --enabled, --score-threshold or --sensitivity-level")
+		}
+	}
+
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	entrypoint, deployment, err := findManagedDeployment(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	var actionParameters map[string]interface{}
+	if err := json.Unmarshal([]byte(deployment.Rule.JSON.ActionParameters.Raw()), &actionParameters); err != nil {
+		return err
+	}
+	overrides, _ := actionParameters["overrides"].(map[string]interface{})
+	before := overrideLines(overrides)
+	if overrides == nil {
+		overrides = make(map[string]interface{})
+	}
+	setOverride(overrides, list, key, value, entry)
+	after := overrideLines(overrides)
+
+	if len(overrides) == 0 {
+		delete(actionParameters, "overrides")
+	} else {
+		actionParameters["overrides"] = overrides
+	}
+
+	if !jsonOutput(c) {
+		fmt.Println(strings.Join(diffLines(before, after), "\n"))
+	}
+	if dryRun {
+		return nil
+	}
+
+	rule := rulesetRuleFromExisting(deployment.Rule)
+	rule.ActionParameters = actionParameters
+
+	rs, err := editRulesetRule(c, "", zoneID, entrypoint.ID, deployment.Rule.ID, rule, nil)
+	if err != nil {
+		return fmt.Errorf("Error updating managed ruleset overrides: %w", err)
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(rs.JSON.RawJSON()))
+	}
+	return nil
+}
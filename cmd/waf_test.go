@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetOverride(t *testing.T) {
+	overrides := map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{"id": "a", "enabled": false},
+			map[string]interface{}{"id": "b", "action": "log"},
+		},
+	}
+
+	setOverride(overrides, "rules", "id", "a", map[string]interface{}{"id": "a", "action": "block"})
+	want := []interface{}{
+		map[string]interface{}{"id": "a", "enabled": false, "action": "block"},
+		map[string]interface{}{"id": "b", "action": "log"},
+	}
+	if !reflect.DeepEqual(overrides["rules"], want) {
+		t.Errorf("after merge, rules = %v; want %v", overrides["rules"], want)
+	}
+
+	setOverride(overrides, "rules", "id", "a", nil)
+	want = []interface{}{map[string]interface{}{"id": "b", "action": "log"}}
+	if !reflect.DeepEqual(overrides["rules"], want) {
+		t.Errorf("after remove, rules = %v; want %v", overrides["rules"], want)
+	}
+
+	setOverride(overrides, "categories", "category", "wordpress", map[string]interface{}{"category": "wordpress", "enabled": false})
+	if got := overrides["categories"].([]interface{}); len(got) != 1 {
+		t.Errorf("after add, categories = %v; want one entry", got)
+	}
+
+	setOverride(overrides, "categories", "category", "wordpress", nil)
+	if _, ok := overrides["categories"]; ok {
+		t.Errorf("after removing the last tag override, categories = %v; want it deleted", overrides["categories"])
+	}
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/workers"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var workersCmd = &cobra.Command{
+	Use:     "workers",
+	Aliases: []string{"worker"},
+	Short:   "Workers scripts of an account",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return ensureClient()
+	},
+}
+
+var workersListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List Workers scripts",
+	RunE:    workersList,
+}
+
+var workersGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the settings and bindings of a Workers script",
+	RunE:  workersGet,
+}
+
+var workersDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a Workers script",
+	RunE:  workersDelete,
+}
+
+var workersTailConfigCmd = &cobra.Command{
+	Use:   "tail-config",
+	Short: "Show, start or stop the log tails of a Workers script",
+	Long: `Show the log tails of a Workers script. --start starts a new tail and
+prints the WebSocket URL to connect to for the logs; --stop stops a tail.`,
+	RunE: workersTailConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(workersCmd)
+	workersCmd.AddCommand(workersListCmd)
+	workersCmd.AddCommand(workersGetCmd)
+	workersCmd.AddCommand(workersDeleteCmd)
+	workersCmd.AddCommand(workersTailConfigCmd)
+
+	for _, c := range []*cobra.Command{workersGetCmd, workersDeleteCmd, workersTailConfigCmd} {
+		c.Flags().String("script", "", "script name")
+	}
+	workersDeleteCmd.Flags().Bool("force", false, "also delete the bindings and Durable Objects that use the script")
+	workersTailConfigCmd.Flags().Bool("start", false, "start a new tail")
+	workersTailConfigCmd.Flags().String("stop", "", "ID of the tail to stop")
+}
+
+// getWorkersAccountID returns the account of the scripts, given with the
+// global --account-id flag.
+func getWorkersAccountID(c *cobra.Command) (string, error) {
+	if err := checkFlags(c, "account-id"); err != nil {
+		return "", err
+	}
+	accountID, _ := c.Flags().GetString("account-id")
+	return accountID, nil
+}
+
+// workerBinding is a binding of a Workers script, in the fields used by the
+// binding types that deploy manages.
+type workerBinding struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	NamespaceID  string `json:"namespace_id,omitempty"`
+	BucketName   string `json:"bucket_name,omitempty"`
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+	Text         string `json:"text,omitempty"`
+}
+
+// value returns the setting of the binding that tells it apart from another
+// binding of the same type. Secret values are never shown; a secret taking a
+// new value is marked as such.
+func (b workerBinding) value() string {
+	switch b.Type {
+	case "kv_namespace":
+		return b.NamespaceID
+	case "r2_bucket":
+		if b.Jurisdiction != "" {
+			return b.BucketName + " (" + b.Jurisdiction + ")"
+		}
+		return b.BucketName
+	case "plain_text":
+		return fmt.Sprintf("%q", b.Text)
+	case "secret_text":
+		if b.Text != "" {
+			return "(new value)"
+		}
+	}
+	return ""
+}
+
+// describe returns a one-line description of the binding. Deploy only
+// inherits secrets, so an inherited binding reads as an unchanged secret.
+func (b workerBinding) describe() string {
+	t := b.Type
+	if t == "inherit" {
+		t = "secret_text"
+	}
+	return strings.TrimSpace(b.Name + " " + t + " " + b.value())
+}
+
+// workerSettings is the part of the script settings that deploy compares.
+type workerSettings struct {
+	CompatibilityDate  string          `json:"compatibility_date"`
+	CompatibilityFlags []string        `json:"compatibility_flags"`
+	Bindings           []workerBinding `json:"bindings"`
+}
+
+// getWorkerSettings returns the settings of the live script, or nil if there
+// is no script of that name.
+func getWorkerSettings(c *cobra.Command, accountID, name string) (*workerSettings, json.RawMessage, error) {
+	res, err := client.Workers.Scripts.ScriptAndVersionSettings.Get(c.Context(), name, workers.ScriptScriptAndVersionSettingGetParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if isNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error getting Workers script settings: %w", err)
+	}
+	raw := json.RawMessage(res.JSON.RawJSON())
+	var s workerSettings
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, nil, fmt.Errorf("Error reading Workers script settings: %w", err)
+	}
+	return &s, raw, nil
+}
+
+func workersList(c *cobra.Command, args []string) error {
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	var scripts []workers.ScriptListResponse
+	iter := client.Workers.Scripts.ListAutoPaging(c.Context(), workers.ScriptListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	for iter.Next() {
+		scripts = append(scripts, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing Workers scripts: %w", err)
+	}
+	sort.SliceStable(scripts, func(i, j int) bool { return scripts[i].ID < scripts[j].ID })
+
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(scripts))
+		for _, s := range scripts {
+			raw = append(raw, json.RawMessage(s.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+	output := make([][]string, 0, len(scripts))
+	for _, s := range scripts {
+		output = append(output, []string{
+			s.ID,
+			formatBool(s.HasModules),
+			s.CompatibilityDate,
+			strings.Join(s.Handlers, ","),
+			s.ModifiedOn.Format(time.RFC3339),
+		})
+	}
+	writeTable(output, "Name", "Modules", "Compatibility Date", "Handlers", "Modified On")
+	return nil
+}
+
+func workersGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "script"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("script")
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	settings, raw, err := getWorkerSettings(c, accountID, name)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		return fmt.Errorf("Workers script %s not found", name)
+	}
+	if jsonOutput(c) {
+		return writeJSON(raw)
+	}
+
+	writeTable([][]string{{name, settings.CompatibilityDate, strings.Join(settings.CompatibilityFlags, ",")}},
+		"Name", "Compatibility Date", "Compatibility Flags")
+	fmt.Println()
+	output := make([][]string, 0, len(settings.Bindings))
+	for _, b := range settings.Bindings {
+		output = append(output, []string{b.Name, b.Type, b.value()})
+	}
+	writeTable(output, "Binding", "Type", "Value")
+	return nil
+}
+
+func workersDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "script"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("script")
+	force, _ := c.Flags().GetBool("force")
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	params := workers.ScriptDeleteParams{AccountID: cloudflare.F(accountID)}
+	if force {
+		params.Force = cloudflare.F(true)
+	}
+	if _, err := client.Workers.Scripts.Delete(c.Context(), name, params); err != nil {
+		return fmt.Errorf("Error deleting Workers script: %w", err)
+	}
+	fmt.Printf("Deleted Workers script %s\n", name)
+	return nil
+}
+
+func workersTailConfig(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "script"); err != nil {
+		return err
+	}
+	name, _ := c.Flags().GetString("script")
+	start, _ := c.Flags().GetBool("start")
+	stop, _ := c.Flags().GetString("stop")
+	if start && stop != "" {
+		return fmt.Errorf("--start and --stop cannot be used together")
+	}
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	if stop != "" {
+		_, err := client.Workers.Scripts.Tail.Delete(c.Context(), name, stop, workers.ScriptTailDeleteParams{
+			AccountID: cloudflare.F(accountID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error stopping tail: %w", err)
+		}
+		fmt.Printf("Stopped tail %s of %s\n", stop, name)
+		return nil
+	}
+
+	var id, url, expiresAt, raw string
+	if start {
+		res, err := client.Workers.Scripts.Tail.New(c.Context(), name, workers.ScriptTailNewParams{
+			AccountID: cloudflare.F(accountID),
+			Body:      map[string]interface{}{},
+		})
+		if err != nil {
+			return fmt.Errorf("Error starting tail: %w", err)
+		}
+		id, url, expiresAt, raw = res.ID, res.URL, res.ExpiresAt, res.JSON.RawJSON()
+	} else {
+		res, err := client.Workers.Scripts.Tail.Get(c.Context(), name, workers.ScriptTailGetParams{
+			AccountID: cloudflare.F(accountID),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting tails: %w", err)
+		}
+		id, url, expiresAt, raw = res.ID, res.URL, res.ExpiresAt, res.JSON.RawJSON()
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(raw))
+	}
+	var output [][]string
+	if id != "" {
+		output = append(output, []string{id, url, expiresAt})
+	}
+	writeTable(output, "ID", "URL", "Expires At")
+	return nil
+}
@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/workers"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var workersDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Upload and deploy an ES module Workers script",
+	Long: `Upload an ES module Workers script described by a JSON manifest and deploy
+it. The bindings are compared with the live script first and the changes are
+printed, followed by the ID of the new version.
+
+  {
+    "name": "api",
+    "main": "dist/index.js",
+    "modules": ["dist/chunk.js", "dist/parser.wasm"],
+    "compatibility_date": "2025-01-15",
+    "compatibility_flags": ["nodejs_compat"],
+    "vars": {"ENVIRONMENT": "production"},
+    "kv_namespaces": [{"binding": "CACHE", "id": "0f2ac74b498b48028cb68387c421e279"}],
+    "r2_buckets": [{"binding": "UPLOADS", "bucket_name": "uploads", "jurisdiction": "eu"}],
+    "secrets": ["API_TOKEN"],
+    "keep_bindings": ["durable_object_namespace"]
+  }
+
+Paths are relative to the manifest. The value of each secret is read from the
+environment variable of the same name; a secret that is not set there keeps
+its current value. Live bindings of the types in keep_bindings are kept, all
+other bindings missing from the manifest are removed.`,
+	RunE: workersDeploy,
+}
+
+func init() {
+	workersCmd.AddCommand(workersDeployCmd)
+	workersDeployCmd.Flags().StringP("manifest", "f", "worker.json", "manifest file")
+	workersDeployCmd.Flags().String("script", "", "script name, instead of the name in the manifest")
+	workersDeployCmd.Flags().String("compatibility-date", "", "compatibility date, instead of the one in the manifest")
+	workersDeployCmd.Flags().Bool("dry-run", false, "show the binding changes without deploying")
+}
+
+// workerManifest describes a Workers script to deploy.
+type workerManifest struct {
+	Name               string            `json:"name"`
+	Main               string            `json:"main"`
+	Modules            []string          `json:"modules"`
+	CompatibilityDate  string            `json:"compatibility_date"`
+	CompatibilityFlags []string          `json:"compatibility_flags"`
+	Vars               map[string]string `json:"vars"`
+	KVNamespaces       []struct {
+		Binding string `json:"binding"`
+		ID      string `json:"id"`
+	} `json:"kv_namespaces"`
+	R2Buckets []struct {
+		Binding      string `json:"binding"`
+		BucketName   string `json:"bucket_name"`
+		Jurisdiction string `json:"jurisdiction"`
+	} `json:"r2_buckets"`
+	Secrets      []string `json:"secrets"`
+	KeepBindings []string `json:"keep_bindings"`
+
+	// dir is the directory of the manifest, which paths are relative to.
+	dir string
+}
+
+// parseWorkerManifest reads a manifest, rejecting unknown keys so that typos
+// do not silently drop a binding.
+func parseWorkerManifest(data []byte, dir string) (*workerManifest, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m workerManifest
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	m.dir = dir
+	return &m, nil
+}
+
+func (m *workerManifest) validate() error {
+	if m.Name == "" {
+		return errors.New("missing name")
+	}
+	if m.Main == "" {
+		return errors.New("missing main module")
+	}
+	if m.CompatibilityDate == "" {
+		return errors.New("missing compatibility_date")
+	}
+	date, err := time.Parse("2006-01-02", m.CompatibilityDate)
+	if err != nil {
+		return fmt.Errorf("invalid compatibility_date %q: must be YYYY-MM-DD", m.CompatibilityDate)
+	}
+	if date.After(time.Now()) {
+		return fmt.Errorf("compatibility_date %s is in the future", m.CompatibilityDate)
+	}
+
+	seen := map[string]bool{}
+	check := func(name string) error {
+		if name == "" {
+			return errors.New("binding without a name")
+		}
+		if seen[name] {
+			return fmt.Errorf("binding %s is declared more than once", name)
+		}
+		seen[name] = true
+		return nil
+	}
+	for name := range m.Vars {
+		if err := check(name); err != nil {
+			return err
+		}
+	}
+	for _, kv := range m.KVNamespaces {
+		if err := check(kv.Binding); err != nil {
+			return err
+		}
+		if kv.ID == "" {
+			return fmt.Errorf("KV binding %s has no id", kv.Binding)
+		}
+	}
+	for _, r2 := range m.R2Buckets {
+		if err := check(r2.Binding); err != nil {
+			return err
+		}
+		if r2.BucketName == "" {
+			return fmt.Errorf("R2 binding %s has no bucket_name", r2.Binding)
+		}
+		if r2.Jurisdiction != "" && !workers.ScriptUpdateParamsMetadataBindingsJurisdiction(r2.Jurisdiction).IsKnown() {
+			return fmt.Errorf("R2 binding %s has an invalid jurisdiction %q", r2.Binding, r2.Jurisdiction)
+		}
+	}
+	for _, name := range m.Secrets {
+		if err := check(name); err != nil {
+			return err
+		}
+	}
+	for _, t := range m.KeepBindings {
+		if !workers.ScriptUpdateParamsMetadataBindingsType(t).IsKnown() {
+			return fmt.Errorf("invalid binding type %q in keep_bindings", t)
+		}
+	}
+	return nil
+}
+
+// workerModule is a file uploaded as part of a script.
+type workerModule struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// workerModuleContentType returns the content type to upload a module with,
+// from its file extension.
+func workerModuleContentType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".js", ".mjs":
+		return "application/javascript+module"
+	case ".cjs":
+		return "application/javascript"
+	case ".wasm":
+		return "application/wasm"
+	case ".py":
+		return "text/x-python"
+	case ".map":
+		return "application/source-map"
+	case ".txt", ".html", ".css", ".json", ".sql":
+		return "text/plain"
+	}
+	return "application/octet-stream"
+}
+
+// modules reads the main module and the other modules. Modules are named by
+// their path relative to the main module, which is how they are imported.
+func (m *workerManifest) modules() ([]workerModule, error) {
+	main := filepath.Join(m.dir, m.Main)
+	base := filepath.Dir(main)
+	var modules []workerModule
+	seen := map[string]bool{}
+	for _, p := range append([]string{m.Main}, m.Modules...) {
+		path := filepath.Join(m.dir, p)
+		rel, err := filepath.Rel(base, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("module %s is not below the directory of the main module", p)
+		}
+		name := filepath.ToSlash(rel)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, workerModule{Name: name, ContentType: workerModuleContentType(name), Data: data})
+	}
+	return modules, nil
+}
+
+// bindings returns the bindings to deploy. Secrets set in the environment get
+// the new value, others are inherited from the live script.
+func (m *workerManifest) bindings(live *workerSettings, getenv func(string) (string, bool)) ([]workerBinding, error) {
+	liveSecrets := map[string]bool{}
+	if live != nil {
+		for _, b := range live.Bindings {
+			if b.Type == "secret_text" {
+				liveSecrets[b.Name] = true
+			}
+		}
+	}
+
+	var bindings []workerBinding
+	for name, text := range m.Vars {
+		bindings = append(bindings, workerBinding{Name: name, Type: "plain_text", Text: text})
+	}
+	for _, kv := range m.KVNamespaces {
+		bindings = append(bindings, workerBinding{Name: kv.Binding, Type: "kv_namespace", NamespaceID: kv.ID})
+	}
+	for _, r2 := range m.R2Buckets {
+		bindings = append(bindings, workerBinding{Name: r2.Binding, Type: "r2_bucket", BucketName: r2.BucketName, Jurisdiction: r2.Jurisdiction})
+	}
+	for _, name := range m.Secrets {
+		switch value, ok := getenv(name); {
+		case ok && value != "":
+			bindings = append(bindings, workerBinding{Name: name, Type: "secret_text", Text: value})
+		case liveSecrets[name]:
+			bindings = append(bindings, workerBinding{Name: name, Type: "inherit"})
+		default:
+			return nil, fmt.Errorf("secret %s is not set in the environment and the script has no such secret yet", name)
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Name < bindings[j].Name })
+	return bindings, nil
+}
+
+// bindingChanges returns the changes from the live bindings to the deployed
+// ones, in the format of diffLines without the unchanged lines. Live bindings
+// of the kept types stay as they are.
+func bindingChanges(live *workerSettings, bindings []workerBinding, keep []string) []string {
+	var before, after []string
+	if live != nil {
+		for _, b := range live.Bindings {
+			before = append(before, b.describe())
+			if slices.Contains(keep, b.Type) {
+				after = append(after, b.describe())
+			}
+		}
+	}
+	for _, b := range bindings {
+		after = append(after, b.describe())
+	}
+	sort.Strings(before)
+	sort.Strings(after)
+
+	var changes []string
+	for _, line := range diffLines(before, after) {
+		if !strings.HasPrefix(line, "  ") {
+			changes = append(changes, line)
+		}
+	}
+	return changes
+}
+
+func bindingParams(bindings []workerBinding) []workers.ScriptUpdateParamsMetadataBindingUnion {
+	params := make([]workers.ScriptUpdateParamsMetadataBindingUnion, 0, len(bindings))
+	for _, b := range bindings {
+		p := workers.ScriptUpdateParamsMetadataBinding{
+			Name: cloudflare.F(b.Name),
+			Type: cloudflare.F(workers.ScriptUpdateParamsMetadataBindingsType(b.Type)),
+		}
+		switch b.Type {
+		case "plain_text", "secret_text":
+			p.Text = cloudflare.F(b.Text)
+		case "kv_namespace":
+			p.NamespaceID = cloudflare.F(b.NamespaceID)
+		case "r2_bucket":
+			p.BucketName = cloudflare.F(b.BucketName)
+			if b.Jurisdiction != "" {
+				p.Jurisdiction = cloudflare.F(workers.ScriptUpdateParamsMetadataBindingsJurisdiction(b.Jurisdiction))
+			}
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// workerVersionID returns the ID of the version created by an upload. The
+// upload result carries it as deployment_id; otherwise the version of the
+// latest deployment is used.
+func workerVersionID(c *cobra.Command, accountID, name string, raw []byte) (string, error) {
+	var res struct {
+		DeploymentID string `json:"deployment_id"`
+	}
+	if err := json.Unmarshal(raw, &res); err == nil && res.DeploymentID != "" {
+		return res.DeploymentID, nil
+	}
+
+	deployments, err := client.Workers.Scripts.Deployments.List(c.Context(), name, workers.ScriptDeploymentListParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error listing deployments: %w", err)
+	}
+	var latest *workers.Deployment
+	for i, d := range deployments.Deployments {
+		if latest == nil || d.CreatedOn.After(latest.CreatedOn) {
+			latest = &deployments.Deployments[i]
+		}
+	}
+	if latest == nil || len(latest.Versions) == 0 {
+		return "", errors.New("no deployment found")
+	}
+	return latest.Versions[0].VersionID, nil
+}
+
+func workersDeploy(c *cobra.Command, args []string) error {
+	manifestFile, _ := c.Flags().GetString("manifest")
+	scriptName, _ := c.Flags().GetString("script")
+	compatibilityDate, _ := c.Flags().GetString("compatibility-date")
+	dryRun, _ := c.Flags().GetBool("dry-run")
+
+	data, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return err
+	}
+	m, err := parseWorkerManifest(data, filepath.Dir(manifestFile))
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", manifestFile, err)
+	}
+	if scriptName != "" {
+		m.Name = scriptName
+	}
+	if compatibilityDate != "" {
+		m.CompatibilityDate = compatibilityDate
+	}
+	if err := m.validate(); err != nil {
+		return fmt.Errorf("%s: %w", manifestFile, err)
+	}
+	modules, err := m.modules()
+	if err != nil {
+		return err
+	}
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	live, _, err := getWorkerSettings(c, accountID, m.Name)
+	if err != nil {
+		return err
+	}
+	bindings, err := m.bindings(live, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	changes := bindingChanges(live, bindings, m.KeepBindings)
+
+	if !jsonOutput(c) {
+		switch {
+		case live == nil:
+			fmt.Printf("New script %s\n", m.Name)
+		case live.CompatibilityDate != m.CompatibilityDate:
+			fmt.Printf("Compatibility date: %s -> %s\n", live.CompatibilityDate, m.CompatibilityDate)
+		}
+		if len(changes) == 0 {
+			fmt.Println("Bindings: no changes")
+		} else {
+			fmt.Println("Bindings:")
+			for _, line := range changes {
+				fmt.Println(line)
+			}
+		}
+	}
+	if dryRun {
+		if jsonOutput(c) {
+			return writeJSON(map[string]interface{}{"script": m.Name, "binding_changes": changes})
+		}
+		return nil
+	}
+
+	files := make([]io.Reader, 0, len(modules))
+	for _, mod := range modules {
+		files = append(files, cloudflare.FileParam(bytes.NewReader(mod.Data), mod.Name, mod.ContentType).Value)
+	}
+	metadata := workers.ScriptUpdateParamsMetadata{
+		MainModule:        cloudflare.F(modules[0].Name),
+		CompatibilityDate: cloudflare.F(m.CompatibilityDate),
+		Bindings:          cloudflare.F(bindingParams(bindings)),
+	}
+	if len(m.CompatibilityFlags) > 0 {
+		metadata.CompatibilityFlags = cloudflare.F(m.CompatibilityFlags)
+	}
+	if len(m.KeepBindings) > 0 {
+		metadata.KeepBindings = cloudflare.F(m.KeepBindings)
+	}
+
+	res, err := client.Workers.Scripts.Update(c.Context(), m.Name, workers.ScriptUpdateParams{
+		AccountID: cloudflare.F(accountID),
+		Metadata:  cloudflare.F(metadata),
+		Files:     cloudflare.F(files),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deploying Workers script: %w", err)
+	}
+	versionID, err := workerVersionID(c, accountID, m.Name, []byte(res.JSON.RawJSON()))
+	if err != nil {
+		return fmt.Errorf("Deployed %s but could not find the version ID: %w", m.Name, err)
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(map[string]interface{}{
+			"script":          m.Name,
+			"version_id":      versionID,
+			"binding_changes": changes,
+			"result":          json.RawMessage(res.JSON.RawJSON()),
+		})
+	}
+	fmt.Printf("Deployed %s version %s\n", m.Name, versionID)
+	return nil
+}
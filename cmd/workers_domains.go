@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/workers"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var workersDomainsCmd = &cobra.Command{
+	Use:   "domains",
+	Short: "Custom domains of Workers scripts",
+}
+
+var workersDomainsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List Workers custom domains",
+	RunE:    workersDomainsList,
+}
+
+var workersDomainsAttachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Attach a hostname to a Workers script",
+	Long: `Serve a hostname of the zone with a Workers script. Cloudflare creates the
+DNS record and certificate for the hostname. A hostname already attached to
+another script is moved to this one.`,
+	RunE: workersDomainsAttach,
+}
+
+var workersDomainsDetachCmd = &cobra.Command{
+	Use:   "detach",
+	Short: "Detach a hostname from its Workers script",
+	RunE:  workersDomainsDetach,
+}
+
+func init() {
+	workersCmd.AddCommand(workersDomainsCmd)
+	workersDomainsCmd.AddCommand(workersDomainsListCmd)
+	workersDomainsCmd.AddCommand(workersDomainsAttachCmd)
+	workersDomainsCmd.AddCommand(workersDomainsDetachCmd)
+
+	workersDomainsListCmd.Flags().String("zone", "", "only domains in this zone")
+	workersDomainsListCmd.Flags().String("script", "", "only domains of this script")
+
+	workersDomainsAttachCmd.Flags().String("zone", "", "zone name")
+	workersDomainsAttachCmd.Flags().String("hostname", "", "hostname to attach")
+	workersDomainsAttachCmd.Flags().String("script", "", "script name")
+	workersDomainsAttachCmd.Flags().String("environment", "", "environment of the script")
+
+	workersDomainsDetachCmd.Flags().String("hostname", "", "hostname to detach")
+	workersDomainsDetachCmd.Flags().String("id", "", "domain ID")
+}
+
+func writeWorkersDomains(c *cobra.Command, domains []workers.Domain) error {
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(domains))
+		for _, d := range domains {
+			raw = append(raw, json.RawMessage(d.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(domains))
+	for _, d := range domains {
+		output = append(output, []string{d.ID, d.Hostname, d.Service, d.Environment, d.ZoneName})
+	}
+	writeTable(output, "ID", "Hostname", "Script", "Environment", "Zone")
+	return nil
+}
+
+func workersDomainsList(c *cobra.Command, args []string) error {
+	zoneName, _ := c.Flags().GetString("zone")
+	script, _ := c.Flags().GetString("script")
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	params := workers.DomainListParams{AccountID: cloudflare.F(accountID)}
+	if zoneName != "" {
+		params.ZoneName = cloudflare.F(zoneName)
+	}
+	if script != "" {
+		params.Service = cloudflare.F(script)
+	}
+	var domains []workers.Domain
+	iter := client.Workers.Domains.ListAutoPaging(c.Context(), params)
+	for iter.Next() {
+		domains = append(domains, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("Error listing Workers domains: %w", err)
+	}
+	sort.SliceStable(domains, func(i, j int) bool { return domains[i].Hostname < domains[j].Hostname })
+	return writeWorkersDomains(c, domains)
+}
+
+func workersDomainsAttach(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "hostname", "script"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	hostname, _ := c.Flags().GetString("hostname")
+	script, _ := c.Flags().GetString("script")
+	environment, _ := c.Flags().GetString("environment")
+
+	hostname = strings.ToLower(hostname)
+	if hostname != strings.ToLower(zoneName) && !strings.HasSuffix(hostname, "."+strings.ToLower(zoneName)) {
+		return fmt.Errorf("%s is not in zone %s", hostname, zoneName)
+	}
+	if strings.Contains(hostname, "*") {
+		return errors.New("custom domains cannot be wildcards; use a route instead")
+	}
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := workers.DomainUpdateParams{
+		AccountID: cloudflare.F(accountID),
+		ZoneID:    cloudflare.F(zoneID),
+		Hostname:  cloudflare.F(hostname),
+		Service:   cloudflare.F(script),
+	}
+	if environment != "" {
+		params.Environment = cloudflare.F(environment)
+	}
+	d, err := client.Workers.Domains.Update(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error attaching Workers domain: %w", err)
+	}
+	return writeWorkersDomains(c, []workers.Domain{*d})
+}
+
+func workersDomainsDetach(c *cobra.Command, args []string) error {
+	hostname, _ := c.Flags().GetString("hostname")
+	id, _ := c.Flags().GetString("id")
+	if (hostname == "") == (id == "") {
+		return errors.New("exactly one of --hostname and --id is required")
+	}
+	accountID, err := getWorkersAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	if hostname != "" {
+		iter := client.Workers.Domains.ListAutoPaging(c.Context(), workers.DomainListParams{
+			AccountID: cloudflare.F(accountID),
+			Hostname:  cloudflare.F(hostname),
+		})
+		for iter.Next() {
+			if d := iter.Current(); strings.EqualFold(d.Hostname, hostname) {
+				id = d.ID
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("Error listing Workers domains: %w", err)
+		}
+		if id == "" {
+			return fmt.Errorf("%s is not attached to a Workers script", hostname)
+		}
+	}
+
+	err = client.Workers.Domains.Delete(c.Context(), id, workers.DomainDeleteParams{
+		AccountID: cloudflare.F(accountID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error detaching Workers domain: %w", err)
+	}
+	if hostname != "" {
+		fmt.Printf("Detached %s (%s)\n", hostname, id)
+	} else {
+		fmt.Printf("Detached domain %s\n", id)
+	}
+	return nil
+}
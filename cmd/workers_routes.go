@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/workers"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var workersRoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "Workers routes of a zone",
+}
+
+var workersRoutesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List Workers routes",
+	RunE:    workersRoutesList,
+}
+
+var workersRoutesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Route a pattern to a Workers script",
+	Long: `Route requests matching a pattern such as "shop.example.com/*" to a Workers
+script. If the pattern already has a route, it is pointed at the script, so
+the command can be run again safely.`,
+	RunE: workersRoutesCreate,
+}
+
+var workersRoutesDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a Workers route",
+	RunE:  workersRoutesDelete,
+}
+
+func init() {
+	workersCmd.AddCommand(workersRoutesCmd)
+	workersRoutesCmd.AddCommand(workersRoutesListCmd)
+	workersRoutesCmd.AddCommand(workersRoutesCreateCmd)
+	workersRoutesCmd.AddCommand(workersRoutesDeleteCmd)
+
+	workersRoutesCmd.PersistentFlags().String("zone", "", "zone name")
+
+	workersRoutesListCmd.Flags().String("script", "", "only routes to this script")
+	workersRoutesCreateCmd.Flags().String("pattern", "", "route pattern, e.g. example.com/api/*")
+	workersRoutesCreateCmd.Flags().String("script", "", "script name")
+	workersRoutesDeleteCmd.Flags().String("pattern", "", "route pattern")
+	workersRoutesDeleteCmd.Flags().String("id", "", "route ID")
+}
+
+// checkRoutePattern checks that a route pattern has no scheme and that its
+// host belongs to the zone.
+func checkRoutePattern(pattern, zone string) error {
+	if strings.Contains(pattern, "://") {
+		return fmt.Errorf("invalid pattern %q: leave out the scheme", pattern)
+	}
+	host, _, _ := strings.Cut(pattern, "/")
+	if host == "" {
+		return fmt.Errorf("invalid pattern %q: missing hostname", pattern)
+	}
+	host = strings.ToLower(strings.TrimPrefix(host, "*"))
+	host = strings.TrimPrefix(host, ".")
+	zone = strings.ToLower(zone)
+	if host != zone && !strings.HasSuffix(host, "."+zone) {
+		return fmt.Errorf("invalid pattern %q: the hostname is not in zone %s", pattern, zone)
+	}
+	return nil
+}
+
+func listWorkersRoutes(c *cobra.Command, zoneID string) ([]workers.RouteListResponse, error) {
+	var routes []workers.RouteListResponse
+	iter := client.Workers.Routes.ListAutoPaging(c.Context(), workers.RouteListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	for iter.Next() {
+		routes = append(routes, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("Error listing Workers routes: %w", err)
+	}
+	return routes, nil
+}
+
+func workersRoutesList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	script, _ := c.Flags().GetString("script")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	routes, err := listWorkersRoutes(c, zoneID)
+	if err != nil {
+		return err
+	}
+	if script != "" {
+		filtered := routes[:0]
+		for _, r := range routes {
+			if r.Script == script {
+				filtered = append(filtered, r)
+			}
+		}
+		routes = filtered
+	}
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].Pattern < routes[j].Pattern })
+
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(routes))
+		for _, r := range routes {
+			raw = append(raw, json.RawMessage(r.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+	output := make([][]string, 0, len(routes))
+	for _, r := range routes {
+		output = append(output, []string{r.ID, r.Pattern, r.Script})
+	}
+	writeTable(output, "ID", "Pattern", "Script")
+	return nil
+}
+
+func workersRoutesCreate(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "pattern", "script"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	pattern, _ := c.Flags().GetString("pattern")
+	script, _ := c.Flags().GetString("script")
+	if err := checkRoutePattern(pattern, zoneName); err != nil {
+		return err
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	routes, err := listWorkersRoutes(c, zoneID)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if r.Pattern != pattern {
+			continue
+		}
+		if r.Script == script {
+			fmt.Printf("Route %s already runs %s (%s)\n", pattern, script, r.ID)
+			return nil
+		}
+		res, err := client.Workers.Routes.Update(c.Context(), r.ID, workers.RouteUpdateParams{
+			ZoneID:  cloudflare.F(zoneID),
+			ID:      cloudflare.F(r.ID),
+			Pattern: cloudflare.F(pattern),
+			Script:  cloudflare.F(script),
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating Workers route: %w", err)
+		}
+		if jsonOutput(c) {
+			return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+		}
+		fmt.Printf("Updated route %s from %s to %s (%s)\n", pattern, r.Script, script, r.ID)
+		return nil
+	}
+
+	res, err := client.Workers.Routes.New(c.Context(), workers.RouteNewParams{
+		ZoneID:  cloudflare.F(zoneID),
+		Pattern: cloudflare.F(pattern),
+		Script:  cloudflare.F(script),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating Workers route: %w", err)
+	}
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(res.JSON.RawJSON()))
+	}
+	fmt.Printf("Created route %s to %s (%s)\n", pattern, script, res.ID)
+	return nil
+}
+
+func workersRoutesDelete(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	pattern, _ := c.Flags().GetString("pattern")
+	id, _ := c.Flags().GetString("id")
+	if (pattern == "") == (id == "") {
+		return errors.New("exactly one of --pattern and --id is required")
+	}
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	if pattern != "" {
+		routes, err := listWorkersRoutes(c, zoneID)
+		if err != nil {
+			return err
+		}
+		for _, r := range routes {
+			if r.Pattern == pattern {
+				id = r.ID
+				break
+			}
+		}
+		if id == "" {
+			return fmt.Errorf("no route for pattern %s", pattern)
+		}
+	}
+
+	if _, err := client.Workers.Routes.Delete(c.Context(), id, workers.RouteDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	}); err != nil {
+		return fmt.Errorf("Error deleting Workers route: %w", err)
+	}
+	fmt.Printf("Deleted route %s\n", id)
+	return nil
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestCheckRoutePattern(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		ok      bool
+	}{
+		{"example.com/*", true},
+		{"shop.example.com/api/*", true},
+		{"*.example.com/*", true},
+		{"*example.com/*", true},
+		{"Shop.Example.com/*", true},
+		{"example.com", true},
+		{"https://example.com/*", false},
+		{"/api/*", false},
+		{"notexample.com/*", false},
+		{"example.org/*", false},
+	} {
+		err := checkRoutePattern(tt.pattern, "example.com")
+		if (err == nil) != tt.ok {
+			t.Errorf("checkRoutePattern(%q) = %v; want ok %v", tt.pattern, err, tt.ok)
+		}
+	}
+}
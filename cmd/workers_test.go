@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testWorkerManifest = `{
+	"name": "api",
+	"main": "dist/index.js",
+	"modules": ["dist/lib/util.js", "dist/parser.wasm"],
+	"compatibility_date": "2025-01-15",
+	"vars": {"ENVIRONMENT": "production"},
+	"kv_namespaces": [{"binding": "CACHE", "id": "kv1"}],
+	"r2_buckets": [{"binding": "UPLOADS", "bucket_name": "uploads", "jurisdiction": "eu"}],
+	"secrets": ["API_TOKEN", "DB_PASSWORD"]
+}`
+
+func TestWorkerManifest(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"dist/index.js", "dist/lib/util.js", "dist/parser.wasm"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := parseWorkerManifest([]byte(testWorkerManifest), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.validate(); err != nil {
+		t.Fatalf("validate() = %v", err)
+	}
+	modules, err := m.modules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][2]string
+	for _, mod := range modules {
+		got = append(got, [2]string{mod.Name, mod.ContentType})
+	}
+	want := [][2]string{
+		{"index.js", "application/javascript+module"},
+		{"lib/util.js", "application/javascript+module"},
+		{"parser.wasm", "application/wasm"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("modules() = %v; want %v", got, want)
+	}
+
+	if _, err := parseWorkerManifest([]byte(`{"name": "api", "kv_namespace": []}`), dir); err == nil {
+		t.Error("parseWorkerManifest(unknown key) = nil error; want error")
+	}
+
+	for name, change := range map[string]func(m *workerManifest){
+		"no main":      func(m *workerManifest) { m.Main = "" },
+		"no date":      func(m *workerManifest) { m.CompatibilityDate = "" },
+		"bad date":     func(m *workerManifest) { m.CompatibilityDate = "15/01/2025" },
+		"future date":  func(m *workerManifest) { m.CompatibilityDate = "2999-01-01" },
+		"duplicate":    func(m *workerManifest) { m.Secrets = append(m.Secrets, "CACHE") },
+		"jurisdiction": func(m *workerManifest) { m.R2Buckets[0].Jurisdiction = "mars" },
+		"keep type":    func(m *workerManifest) { m.KeepBindings = []string{"nonsense"} },
+	} {
+		m, _ := parseWorkerManifest([]byte(testWorkerManifest), dir)
+		change(m)
+		if err := m.validate(); err == nil {
+			t.Errorf("validate(%s) = nil error; want error", name)
+		}
+	}
+
+	m.Modules = []string{"../outside.js"}
+	if _, err := m.modules(); err == nil {
+		t.Error("modules(outside) = nil error; want error")
+	}
+}
+
+func TestWorkerBindings(t *testing.T) {
+	m, err := parseWorkerManifest([]byte(testWorkerManifest), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := &workerSettings{Bindings: []workerBinding{
+		{Name: "CACHE", Type: "kv_namespace", NamespaceID: "kv0"},
+		{Name: "DB_PASSWORD", Type: "secret_text"},
+		{Name: "ENVIRONMENT", Type: "plain_text", Text: "production"},
+		{Name: "QUEUE", Type: "queue"},
+		{Name: "COUNTER", Type: "durable_object_namespace"},
+	}}
+	env := map[string]string{"API_TOKEN": "secret"}
+	getenv := func(name string) (string, bool) { v, ok := env[name]; return v, ok }
+
+	bindings, err := m.bindings(live, getenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, b := range bindings {
+		got = append(got, b.Type+" "+b.Name)
+	}
+	want := []string{"secret_text API_TOKEN", "kv_namespace CACHE", "inherit DB_PASSWORD", "plain_text ENVIRONMENT", "r2_bucket UPLOADS"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bindings() = %v; want %v", got, want)
+	}
+
+	changes := bindingChanges(live, bindings, []string{"durable_object_namespace"})
+	wantChanges := []string{
+		"- CACHE kv_namespace kv0",
+		"+ API_TOKEN secret_text (new value)",
+		"+ CACHE kv_namespace kv1",
+		"- QUEUE queue",
+		"+ UPLOADS r2_bucket uploads (eu)",
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("bindingChanges() = %q; want %q", changes, wantChanges)
+	}
+
+	if _, err := m.bindings(nil, getenv); err == nil {
+		t.Error("bindings(no live secret, not in environment) = nil error; want error")
+	}
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/dns"
 	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/goccy/go-json"
 	"github.com/spf13/cobra"
 )
 
@@ -49,52 +54,270 @@ var zoneDeleteCmd = &cobra.Command{
 	},
 }
 
+var zonePauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause Cloudflare on a zone, serving DNS only",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return zoneSetPaused(cmd, true)
+	},
+}
+
+var zoneUnpauseCmd = &cobra.Command{
+	Use:   "unpause",
+	Short: "Resume Cloudflare on a paused zone",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return zoneSetPaused(cmd, false)
+	},
+}
+
+var zoneActivationCheckCmd = &cobra.Command{
+	Use:   "activation-check",
+	Short: "Re-trigger the name server check of a pending zone",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return zoneActivationCheck(cmd)
+	},
+}
+
+var zonePlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the available plans and current subscription of a zone",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return zonePlan(cmd)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(zoneCmd)
 	zoneCmd.AddCommand(zoneListCmd)
 	zoneCmd.AddCommand(zoneCreateCmd)
 	zoneCmd.AddCommand(zoneInfoCmd)
 	zoneCmd.AddCommand(zoneDeleteCmd)
+	zoneCmd.AddCommand(zonePauseCmd)
+	zoneCmd.AddCommand(zoneUnpauseCmd)
+	zoneCmd.AddCommand(zoneActivationCheckCmd)
+	zoneCmd.AddCommand(zonePlanCmd)
+
+	zoneListCmd.Flags().String("status", "", "only zones with this status: initializing, pending, active or moved")
+	zoneListCmd.Flags().String("name-contains", "", "only zones whose name contains this string")
+	zoneListCmd.Flags().String("account", "", "only zones in this account name or ID")
+	zoneListCmd.Flags().String("plan", "", "only zones on this plan name or ID, e.g. pro")
+	zoneListCmd.Flags().String("sort", "", "sort by name, status, account.id, account.name or plan.id; prefix with - to reverse")
+	zoneListCmd.Flags().Int("limit", 0, "list at most this many zones")
+	zoneListCmd.Flags().String("columns", "id,name,plan,status", "comma-separated columns: id, name, plan, status, type, account, created, activated, name-servers, registrar, paused")
 
 	zoneCreateCmd.Flags().String("zone", "", "zone name")
 	_ = zoneCreateCmd.MarkFlagRequired("zone")
-	zoneCreateCmd.Flags().Bool("jumpstart", false, "automatically fetch DNS records (ignored)")
+	zoneCreateCmd.Flags().String("account", "", "account name or ID to create the zone in")
+	zoneCreateCmd.Flags().String("type", string(zones.TypeFull), "zone type: full, partial or secondary")
+	zoneCreateCmd.Flags().Bool("jumpstart", false, "scan public DNS and import the records found")
+	zoneCreateCmd.Flags().Duration("scan-timeout", 30*time.Second, "how long to wait for the jump-start DNS scan")
 
 	zoneInfoCmd.Flags().String("zone", "", "zone name")
 
 	zoneDeleteCmd.Flags().String("zone", "", "zone name")
 	_ = zoneDeleteCmd.MarkFlagRequired("zone")
+
+	zonePauseCmd.Flags().String("zone", "", "zone name")
+	_ = zonePauseCmd.MarkFlagRequired("zone")
+
+	zoneUnpauseCmd.Flags().String("zone", "", "zone name")
+	_ = zoneUnpauseCmd.MarkFlagRequired("zone")
+
+	zoneActivationCheckCmd.Flags().String("zone", "", "zone name")
+	_ = zoneActivationCheckCmd.MarkFlagRequired("zone")
+
+	zonePlanCmd.Flags().String("zone", "", "zone name")
+	_ = zonePlanCmd.MarkFlagRequired("zone")
+}
+
+// zoneColumn is an optional column of the zone list.
+type zoneColumn struct {
+	header string
+	value  func(z zones.Zone) string
+}
+
+func formatZoneTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+var zoneColumns = map[string]zoneColumn{
+	"id":           {"ID", func(z zones.Zone) string { return z.ID }},
+	"name":         {"Name", func(z zones.Zone) string { return z.Name }},
+	"plan":         {"Plan", func(z zones.Zone) string { return z.Plan.Name }},
+	"status":       {"Status", func(z zones.Zone) string { return string(z.Status) }},
+	"type":         {"Type", func(z zones.Zone) string { return string(z.Type) }},
+	"account":      {"Account", func(z zones.Zone) string { return z.Account.Name }},
+	"created":      {"Created", func(z zones.Zone) string { return formatZoneTime(z.CreatedOn) }},
+	"activated":    {"Activated", func(z zones.Zone) string { return formatZoneTime(z.ActivatedOn) }},
+	"name-servers": {"Name Servers", func(z zones.Zone) string { return strings.Join(z.NameServers, ", ") }},
+	"registrar":    {"Original Registrar", func(z zones.Zone) string { return z.OriginalRegistrar }},
+	"paused":       {"Paused", func(z zones.Zone) string { return formatBool(z.Paused) }},
+}
+
+// getZoneColumns looks up the comma-separated --columns flag.
+func getZoneColumns(columns string) ([]zoneColumn, error) {
+	var cols []zoneColumn
+	for _, name := range strings.Split(columns, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		col, ok := zoneColumns[name]
+		if !ok {
+			names := make([]string, 0, len(zoneColumns))
+			for n := range zoneColumns {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown column %q: must be one of %s", name, strings.Join(names, ", "))
+		}
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return cols, nil
+}
+
+// isAccountID reports whether s looks like an account ID rather than a name.
+func isAccountID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// zoneListParams maps the zone list flags to server-side filters.
+func zoneListParams(c *cobra.Command) (zones.ZoneListParams, error) {
+	status, _ := c.Flags().GetString("status")
+	nameContains, _ := c.Flags().GetString("name-contains")
+	account, _ := c.Flags().GetString("account")
+	sortBy, _ := c.Flags().GetString("sort")
+
+	params := zones.ZoneListParams{}
+	if status != "" {
+		if !zones.ZoneListParamsStatus(status).IsKnown() {
+			return params, fmt.Errorf("invalid status %q: must be initializing, pending, active or moved", status)
+		}
+		params.Status = cloudflare.F(zones.ZoneListParamsStatus(status))
+	}
+	if nameContains != "" {
+		params.Name = cloudflare.F("contains:" + nameContains)
+	}
+	if account != "" {
+		if isAccountID(account) {
+			params.Account = cloudflare.F(zones.ZoneListParamsAccount{ID: cloudflare.F(account)})
+		} else {
+			params.Account = cloudflare.F(zones.ZoneListParamsAccount{Name: cloudflare.F(account)})
+		}
+	}
+	if sortBy != "" {
+		// A leading "-" sorts in descending order.
+		direction := zones.ZoneListParamsDirectionAsc
+		if strings.HasPrefix(sortBy, "-") {
+			direction = zones.ZoneListParamsDirectionDesc
+			sortBy = sortBy[1:]
+		}
+		if !zones.ZoneListParamsOrder(sortBy).IsKnown() {
+			return params, fmt.Errorf("invalid sort field %q: must be name, status, account.id, account.name or plan.id", sortBy)
+		}
+		params.Order = cloudflare.F(zones.ZoneListParamsOrder(sortBy))
+		params.Direction = cloudflare.F(direction)
+	}
+	return params, nil
 }
 
 func zoneList(c *cobra.Command) error {
+	plan, _ := c.Flags().GetString("plan")
+	limit, _ := c.Flags().GetInt("limit")
+	columns, _ := c.Flags().GetString("columns")
+
+	cols, err := getZoneColumns(columns)
+	if err != nil {
+		return err
+	}
+	params, err := zoneListParams(c)
+	if err != nil {
+		return err
+	}
+	// The plan is filtered locally, so small pages only help without it. The
+	// API takes at least 5 per page; the list is cut to the limit below.
+	if limit > 0 && limit < 50 && plan == "" {
+		params.PerPage = cloudflare.F(float64(max(limit, 5)))
+	}
+
 	// ListAutoPaging to get all zones
-	pager := client.Zones.ListAutoPaging(c.Context(), zones.ZoneListParams{})
+	pager := client.Zones.ListAutoPaging(c.Context(), params)
 
-	output := make([][]string, 0)
+	var list []zones.Zone
 	for pager.Next() {
 		z := pager.Current()
-		output = append(output, []string{
-			z.ID,
-			z.Name,
-			z.Plan.Name,
-			string(z.Status),
-		})
+		if plan != "" && !strings.EqualFold(z.Plan.Name, plan) && !strings.EqualFold(z.Plan.LegacyID, plan) {
+			continue
+		}
+		list = append(list, z)
+		if limit > 0 && len(list) >= limit {
+			break
+		}
 	}
 	if err := pager.Err(); err != nil {
 		return err
 	}
 
-	writeTable(output, "ID", "Name", "Plan", "Status")
+	if jsonOutput(c) {
+		raw := make([]json.RawMessage, 0, len(list))
+		for _, z := range list {
+			raw = append(raw, json.RawMessage(z.JSON.RawJSON()))
+		}
+		return writeJSON(raw)
+	}
+
+	output := make([][]string, 0, len(list))
+	for _, z := range list {
+		row := make([]string, 0, len(cols))
+		for _, col := range cols {
+			row = append(row, col.value(z))
+		}
+		output = append(output, row)
+	}
+
+	headers := make([]string, 0, len(cols))
+	for _, col := range cols {
+		headers = append(headers, col.header)
+	}
+	writeTable(output, headers...)
 	return nil
 }
 
 func zoneCreate(c *cobra.Command) error {
 	zoneName, _ := c.Flags().GetString("zone")
 	accountID, _ := c.Flags().GetString("account-id")
+	accountName, _ := c.Flags().GetString("account")
+	zoneType, _ := c.Flags().GetString("type")
+	jumpstart, _ := c.Flags().GetBool("jumpstart")
+	scanTimeout, _ := c.Flags().GetDuration("scan-timeout")
+
+	if !zones.Type(zoneType).IsKnown() || zones.Type(zoneType) == zones.TypeInternal {
+		return fmt.Errorf("invalid zone type %q: must be full, partial or secondary", zoneType)
+	}
+	if accountName != "" {
+		var err error
+		if accountID, err = getAccountID(c); err != nil {
+			return err
+		}
+	}
 
 	params := zones.ZoneNewParams{
 		Name: cloudflare.F(zoneName),
-		Type: cloudflare.F(zones.TypeFull),
+		Type: cloudflare.F(zones.Type(zoneType)),
 	}
 
 	if accountID != "" {
@@ -103,8 +326,120 @@ func zoneCreate(c *cobra.Command) error {
 		})
 	}
 
-	_, err := client.Zones.New(c.Context(), params)
-	return err
+	z, err := client.Zones.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error creating zone: %w", err)
+	}
+
+	// The zone exists even if the jump-start fails, so its details are
+	// still shown before the error is returned.
+	var records []dns.RecordResponse
+	var jumpstartErr error
+	if jumpstart {
+		records, jumpstartErr = jumpstartZone(c, z.ID, scanTimeout)
+	}
+
+	if jsonOutput(c) {
+		if err := writeJSON(struct {
+			Zone    json.RawMessage      `json:"zone"`
+			Records []dns.RecordResponse `json:"records,omitempty"`
+		}{json.RawMessage(z.JSON.RawJSON()), records}); err != nil {
+			return err
+		}
+		return jumpstartErr
+	}
+
+	writeTable([][]string{{
+		z.ID,
+		z.Name,
+		string(z.Type),
+		string(z.Status),
+		strings.Join(z.NameServers, ", "),
+	}}, "ID", "Zone", "Type", "Status", "Name Servers")
+
+	if jumpstart {
+		fmt.Printf("\nImported %d DNS records\n", len(records))
+		output := make([][]string, 0, len(records))
+		for _, r := range records {
+			output = append(output, formatDNSRecord(r))
+		}
+		writeTable(output, "ID", "Name", "Type", "Content", "TTL", "Proxiable", "Proxy")
+	}
+	return jumpstartErr
+}
+
+// jumpstartZone scans public DNS for records of a new zone and accepts the
+// discovered records into it. The asynchronous scan keeps finding records for
+// a while, so results are polled until they stop changing or timeout passes.
+func jumpstartZone(c *cobra.Command, zoneID string, timeout time.Duration) ([]dns.RecordResponse, error) {
+	_, err := client.DNS.Records.ScanTrigger(c.Context(), dns.RecordScanTriggerParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error starting DNS scan: %w", err)
+	}
+
+	var found []dns.RecordResponse
+	deadline := time.Now().Add(timeout)
+	for {
+		res, err := client.DNS.Records.ScanList(c.Context(), dns.RecordScanListParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error listing scanned DNS records: %w", err)
+		}
+		stable := len(res.Result) > 0 && len(res.Result) == len(found)
+		found = res.Result
+		if stable || !time.Now().Add(jumpstartPollInterval).Before(deadline) {
+			break
+		}
+		select {
+		case <-c.Context().Done():
+			return nil, c.Context().Err()
+		case <-time.After(jumpstartPollInterval):
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	accepts := make([]dns.RecordScanReviewParamsAcceptUnion, 0, len(found))
+	for _, r := range found {
+		accepts = append(accepts, scanAcceptFromRecord(r))
+	}
+	res, err := client.DNS.Records.ScanReview(c.Context(), dns.RecordScanReviewParams{
+		ZoneID:  cloudflare.F(zoneID),
+		Accepts: cloudflare.F(accepts),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error accepting scanned DNS records: %w", err)
+	}
+	return res.Accepts, nil
+}
+
+const jumpstartPollInterval = 5 * time.Second
+
+// scanAcceptFromRecord turns a record found by the DNS scan into the form the
+// scan review endpoint accepts.
+func scanAcceptFromRecord(r dns.RecordResponse) dns.RecordScanReviewParamsAccept {
+	accept := dns.RecordScanReviewParamsAccept{
+		Name:    cloudflare.F(r.Name),
+		TTL:     cloudflare.F(r.TTL),
+		Type:    cloudflare.F(dns.RecordScanReviewParamsAcceptsType(r.Type)),
+		Proxied: cloudflare.F(r.Proxied),
+	}
+	if r.Content != "" {
+		accept.Content = cloudflare.F(r.Content)
+	}
+	switch r.Type {
+	case dns.RecordResponseTypeMX, dns.RecordResponseTypeSRV, dns.RecordResponseTypeURI:
+		accept.Priority = cloudflare.F(r.Priority)
+	}
+	if r.JSON.Data.Raw() != "" && !r.JSON.Data.IsNull() {
+		accept.Data = cloudflare.F[interface{}](json.RawMessage(r.JSON.Data.Raw()))
+	}
+	return accept
 }
 
 func zoneInfo(c *cobra.Command, args []string) error {
@@ -136,6 +471,12 @@ func zoneInfo(c *cobra.Command, args []string) error {
 			nameservers = z.NameServers
 		}
 
+		// The hold is informational here; tokens without access to it
+		// still get the rest of the zone details.
+		hold, _ := client.Zones.Holds.Get(c.Context(), zones.HoldGetParams{
+			ZoneID: cloudflare.F(z.ID),
+		})
+
 		output = append(output, []string{
 			z.ID,
 			z.Name,
@@ -144,13 +485,14 @@ func zoneInfo(c *cobra.Command, args []string) error {
 			strings.Join(nameservers, ", "),
 			formatBool(z.Paused),
 			string(z.Type),
+			formatZoneHold(hold),
 		})
 	}
 	if err := pager.Err(); err != nil {
 		return err
 	}
 
-	writeTable(output, "ID", "Zone", "Plan", "Status", "Name Servers", "Paused", "Type")
+	writeTable(output, "ID", "Zone", "Plan", "Status", "Name Servers", "Paused", "Type", "Hold")
 	return nil
 }
 
@@ -170,3 +512,105 @@ func zoneDelete(c *cobra.Command) error {
 	_, err = client.Zones.Delete(c.Context(), params)
 	return err
 }
+
+func zoneSetPaused(c *cobra.Command, paused bool) error {
+	zoneName, _ := c.Flags().GetString("zone")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	z, err := client.Zones.Edit(c.Context(), zones.ZoneEditParams{
+		ZoneID: cloudflare.F(zoneID),
+		Paused: cloudflare.F(paused),
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating zone: %w", err)
+	}
+
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(z.JSON.RawJSON()))
+	}
+	writeTable([][]string{{z.ID, z.Name, string(z.Status), formatBool(z.Paused)}}, "ID", "Zone", "Status", "Paused")
+	return nil
+}
+
+func zoneActivationCheck(c *cobra.Command) error {
+	zoneName, _ := c.Flags().GetString("zone")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Zones.ActivationCheck.Trigger(c.Context(), zones.ActivationCheckTriggerParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error triggering activation check: %w", err)
+	}
+
+	fmt.Printf("Activation check triggered for %s\n", zoneName)
+	return nil
+}
+
+func zonePlan(c *cobra.Command) error {
+	zoneName, _ := c.Flags().GetString("zone")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Zones.Plans.List(c.Context(), zones.PlanListParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing plans: %w", err)
+	}
+	plans := res.Result
+
+	sub, err := client.Zones.Subscriptions.Get(c.Context(), zones.SubscriptionGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error getting subscription: %w", err)
+	}
+
+	if jsonOutput(c) {
+		out := struct {
+			Plans        []zones.AvailableRatePlan `json:"plans"`
+			Subscription json.RawMessage           `json:"subscription,omitempty"`
+		}{Plans: plans}
+		if sub != nil {
+			out.Subscription = json.RawMessage(sub.JSON.RawJSON())
+		}
+		return writeJSON(out)
+	}
+
+	output := make([][]string, 0, len(plans))
+	for _, p := range plans {
+		output = append(output, []string{
+			p.ID,
+			p.Name,
+			fmt.Sprintf("%.2f %s", p.Price, p.Currency),
+			string(p.Frequency),
+			formatBool(p.IsSubscribed),
+			formatBool(p.CanSubscribe),
+		})
+	}
+	writeTable(output, "ID", "Name", "Price", "Frequency", "Subscribed", "Can Subscribe")
+
+	if sub != nil {
+		fmt.Println()
+		writeTable([][]string{{
+			sub.RatePlan.PublicName,
+			string(sub.State),
+			fmt.Sprintf("%.2f %s", sub.Price, sub.Currency),
+			string(sub.Frequency),
+			sub.CurrentPeriodEnd.Format("2006-01-02"),
+		}}, "Subscription", "State", "Price", "Frequency", "Period End")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var zoneHoldCmd = &cobra.Command{
+	Use:   "hold",
+	Short: "Block the zone from being created on other accounts",
+}
+
+var zoneHoldGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the hold of a zone",
+	RunE:  zoneHoldGet,
+}
+
+var zoneHoldEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable the hold of a zone",
+	RunE:  zoneHoldEnable,
+}
+
+var zoneHoldDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disable the hold of a zone, indefinitely or until a given time",
+	RunE:  zoneHoldDisable,
+}
+
+func init() {
+	zoneCmd.AddCommand(zoneHoldCmd)
+	zoneHoldCmd.AddCommand(zoneHoldGetCmd)
+	zoneHoldCmd.AddCommand(zoneHoldEnableCmd)
+	zoneHoldCmd.AddCommand(zoneHoldDisableCmd)
+
+	zoneHoldGetCmd.Flags().String("zone", "", "zone name")
+
+	zoneHoldEnableCmd.Flags().String("zone", "", "zone name")
+	zoneHoldEnableCmd.Flags().Bool("include-subdomains", false, "also block subdomains and custom hostnames of the zone")
+
+	zoneHoldDisableCmd.Flags().String("zone", "", "zone name")
+	zoneHoldDisableCmd.Flags().String("hold-after", "", "re-enable the hold at this date (2006-01-02) or RFC3339 time")
+}
+
+// parseHoldAfter accepts a date or an RFC3339 time and returns the RFC3339
+// form the API expects.
+func parseHoldAfter(s string) (string, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return "", fmt.Errorf("invalid hold-after %q: must be a date (2006-01-02) or RFC3339 time", s)
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+// formatZoneHold summarises a hold for the zone info table.
+func formatZoneHold(h *zones.ZoneHold) string {
+	switch {
+	case h == nil:
+		return ""
+	case h.Hold && h.IncludeSubdomains == "true":
+		return "on (with subdomains)"
+	case h.Hold:
+		return "on"
+	case h.HoldAfter != "":
+		return fmt.Sprintf("off until %s", h.HoldAfter)
+	}
+	return "off"
+}
+
+func writeZoneHold(c *cobra.Command, zoneName string, h *zones.ZoneHold) error {
+	if jsonOutput(c) {
+		return writeJSON(json.RawMessage(h.JSON.RawJSON()))
+	}
+	writeTable([][]string{{
+		zoneName,
+		formatBool(h.Hold),
+		h.IncludeSubdomains,
+		h.HoldAfter,
+	}}, "Zone", "Hold", "Include Subdomains", "Hold After")
+	return nil
+}
+
+func zoneHoldGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	h, err := client.Zones.Holds.Get(c.Context(), zones.HoldGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting zone hold: %w", err)
+	}
+	return writeZoneHold(c, zoneName, h)
+}
+
+func zoneHoldEnable(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	includeSubdomains, _ := c.Flags().GetBool("include-subdomains")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	params := zones.HoldNewParams{
+		ZoneID: cloudflare.F(zoneID),
+	}
+	if c.Flags().Changed("include-subdomains") {
+		params.IncludeSubdomains = cloudflare.F(includeSubdomains)
+	}
+
+	h, err := client.Zones.Holds.New(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error enabling zone hold: %w", err)
+	}
+	return writeZoneHold(c, zoneName, h)
+}
+
+func zoneHoldDisable(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	holdAfter, _ := c.Flags().GetString("hold-after")
+
+	params := zones.HoldDeleteParams{}
+	if holdAfter != "" {
+		t, err := parseHoldAfter(holdAfter)
+		if err != nil {
+			return err
+		}
+		params.HoldAfter = cloudflare.F(t)
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	params.ZoneID = cloudflare.F(zoneID)
+
+	h, err := client.Zones.Holds.Delete(c.Context(), params)
+	if err != nil {
+		return fmt.Errorf("Error disabling zone hold: %w", err)
+	}
+	return writeZoneHold(c, zoneName, h)
+}
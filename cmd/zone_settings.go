@@ -0,0 +1,584 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v6"
+	"github.com/cloudflare/cloudflare-go/v6/zones"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+var zoneSettingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Read and change zone settings",
+}
+
+var zoneSettingsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l"},
+	Short:   "List all settings of a zone",
+	RunE:    zoneSettingsList,
+}
+
+var zoneSettingsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show one zone setting",
+	RunE:  zoneSettingsGet,
+}
+
+var zoneSettingsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change one zone setting",
+	RunE:  zoneSettingsSet,
+}
+
+var zoneSettingsDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the settings of a zone with a baseline file",
+	RunE:  zoneSettingsDiff,
+}
+
+var zoneSettingsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the editable settings of a zone as a baseline file",
+	RunE:  zoneSettingsExport,
+}
+
+var zoneSettingsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Change the settings of one or more zones to match a baseline file",
+	RunE:  zoneSettingsApply,
+}
+
+func init() {
+	zoneCmd.AddCommand(zoneSettingsCmd)
+	zoneSettingsCmd.AddCommand(zoneSettingsListCmd)
+	zoneSettingsCmd.AddCommand(zoneSettingsGetCmd)
+	zoneSettingsCmd.AddCommand(zoneSettingsSetCmd)
+	zoneSettingsCmd.AddCommand(zoneSettingsDiffCmd)
+	zoneSettingsCmd.AddCommand(zoneSettingsExportCmd)
+	zoneSettingsCmd.AddCommand(zoneSettingsApplyCmd)
+
+	zoneSettingsListCmd.Flags().String("zone", "", "zone name")
+
+	zoneSettingsGetCmd.Flags().String("zone", "", "zone name")
+	zoneSettingsGetCmd.Flags().String("setting", "", "setting ID, e.g. ssl or min_tls_version")
+
+	zoneSettingsSetCmd.Flags().String("zone", "", "zone name")
+	zoneSettingsSetCmd.Flags().String("setting", "", "setting ID, e.g. ssl or min_tls_version")
+	zoneSettingsSetCmd.Flags().String("value", "", "new value; objects are given as JSON")
+
+	zoneSettingsDiffCmd.Flags().String("zone", "", "zone name")
+	zoneSettingsDiffCmd.Flags().StringP("file", "f", "", "baseline file")
+
+	zoneSettingsExportCmd.Flags().String("zone", "", "zone name")
+	zoneSettingsExportCmd.Flags().StringP("file", "f", "", "write to this file instead of stdout")
+
+	zoneSettingsApplyCmd.Flags().StringArray("zone", nil, "zone name (repeatable)")
+	zoneSettingsApplyCmd.Flags().StringP("file", "f", "", "baseline file")
+	zoneSettingsApplyCmd.Flags().Bool("dry-run", false, "only show the changes")
+}
+
+type zoneSettingKind int
+
+const (
+	zoneSettingOnOff zoneSettingKind = iota
+	zoneSettingEnum
+	zoneSettingInt
+	zoneSettingList
+	zoneSettingObject
+)
+
+type zoneSettingType struct {
+	kind   zoneSettingKind
+	values []string
+}
+
+func onOffSetting() zoneSettingType { return zoneSettingType{kind: zoneSettingOnOff} }
+
+func enumSetting(values ...string) zoneSettingType {
+	return zoneSettingType{kind: zoneSettingEnum, values: values}
+}
+
+// zoneSettingTypes describes the values of the well-known zone settings.
+// Settings that are not listed take a JSON value or a plain string.
+var zoneSettingTypes = map[string]zoneSettingType{
+	"0rtt":                            onOffSetting(),
+	"always_online":                   onOffSetting(),
+	"always_use_https":                onOffSetting(),
+	"automatic_https_rewrites":        onOffSetting(),
+	"brotli":                          onOffSetting(),
+	"browser_check":                   onOffSetting(),
+	"development_mode":                onOffSetting(),
+	"early_hints":                     onOffSetting(),
+	"email_obfuscation":               onOffSetting(),
+	"hotlink_protection":              onOffSetting(),
+	"http2":                           onOffSetting(),
+	"http3":                           onOffSetting(),
+	"ip_geolocation":                  onOffSetting(),
+	"ipv6":                            onOffSetting(),
+	"mirage":                          onOffSetting(),
+	"opportunistic_encryption":        onOffSetting(),
+	"opportunistic_onion":             onOffSetting(),
+	"orange_to_orange":                onOffSetting(),
+	"origin_error_page_pass_thru":     onOffSetting(),
+	"prefetch_preload":                onOffSetting(),
+	"privacy_pass":                    onOffSetting(),
+	"response_buffering":              onOffSetting(),
+	"rocket_loader":                   onOffSetting(),
+	"server_side_exclude":             onOffSetting(),
+	"sort_query_string_for_cache":     onOffSetting(),
+	"tls_client_auth":                 onOffSetting(),
+	"true_client_ip_header":           onOffSetting(),
+	"webp":                            onOffSetting(),
+	"websockets":                      onOffSetting(),
+	"cache_level":                     enumSetting("aggressive", "basic", "simplified"),
+	"h2_prioritization":               enumSetting("on", "off", "custom"),
+	"image_resizing":                  enumSetting("on", "off", "open"),
+	"min_tls_version":                 enumSetting("1.0", "1.1", "1.2", "1.3"),
+	"origin_max_http_version":         enumSetting("1", "2"),
+	"polish":                          enumSetting("off", "lossless", "lossy"),
+	"pseudo_ipv4":                     enumSetting("off", "add_header", "overwrite_header"),
+	"security_level":                  enumSetting("off", "essentially_off", "low", "medium", "high", "under_attack"),
+	"ssl":                             enumSetting("off", "flexible", "full", "strict"),
+	"tls_1_3":                         enumSetting("on", "off", "zrt"),
+	"browser_cache_ttl":               {kind: zoneSettingInt},
+	"challenge_ttl":                   {kind: zoneSettingInt},
+	"edge_cache_ttl":                  {kind: zoneSettingInt},
+	"max_upload":                      {kind: zoneSettingInt},
+	"ciphers":                         {kind: zoneSettingList},
+	"automatic_platform_optimization": {kind: zoneSettingObject},
+	"minify":                          {kind: zoneSettingObject},
+	"mobile_redirect":                 {kind: zoneSettingObject},
+	"nel":                             {kind: zoneSettingObject},
+	"security_header":                 {kind: zoneSettingObject},
+}
+
+// parseZoneSettingValue converts a value given on the command line to the
+// type the API expects for the setting.
+func parseZoneSettingValue(id, value string) (interface{}, error) {
+	t, ok := zoneSettingTypes[id]
+	if !ok {
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err == nil {
+			return v, nil
+		}
+		return value, nil
+	}
+
+	switch t.kind {
+	case zoneSettingOnOff:
+		switch strings.ToLower(value) {
+		case "on", "true", "1":
+			return "on", nil
+		case "off", "false", "0":
+			return "off", nil
+		}
+		return nil, fmt.Errorf("invalid value %q for %s: must be on or off", value, id)
+	case zoneSettingEnum:
+		for _, v := range t.values {
+			if strings.EqualFold(v, value) {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid value %q for %s: must be one of %s", value, id, strings.Join(t.values, ", "))
+	case zoneSettingInt:
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s: must be a non-negative integer", value, id)
+		}
+		return n, nil
+	case zoneSettingList:
+		list := make([]string, 0)
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+		return list, nil
+	default:
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: must be a JSON object: %w", id, err)
+		}
+		return v, nil
+	}
+}
+
+// normalizeZoneSettingValue checks a value read from a baseline file, parsing
+// strings, booleans and numbers the same way as on the command line.
+func normalizeZoneSettingValue(id string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return parseZoneSettingValue(id, v)
+	case bool:
+		return parseZoneSettingValue(id, strconv.FormatBool(v))
+	case float64:
+		return parseZoneSettingValue(id, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return value, nil
+}
+
+// zoneSetting is one entry of the zone settings list.
+type zoneSetting struct {
+	ID            string          `json:"id"`
+	Value         json.RawMessage `json:"value"`
+	Editable      bool            `json:"editable"`
+	ModifiedOn    string          `json:"modified_on"`
+	TimeRemaining *float64        `json:"time_remaining,omitempty"`
+}
+
+// getZoneSettings returns all settings of a zone. The bulk endpoint is not
+// part of the zones.Settings service, so it is called directly.
+func getZoneSettings(c *cobra.Command, zoneID string) ([]zoneSetting, error) {
+	var res struct {
+		Result  []zoneSetting `json:"result"`
+		Success bool          `json:"success"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := client.Get(c.Context(), fmt.Sprintf("zones/%s/settings", zoneID), nil, &res); err != nil {
+		return nil, fmt.Errorf("Error listing zone settings: %w", err)
+	}
+	if !res.Success {
+		if len(res.Errors) > 0 {
+			return nil, fmt.Errorf("api error: %s", res.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("api error: unknown error")
+	}
+
+	sort.Slice(res.Result, func(i, j int) bool { return res.Result[i].ID < res.Result[j].ID })
+	return res.Result, nil
+}
+
+// canonicalSettingValue returns a compact JSON form of a value, so values
+// read from the API and from a baseline file can be compared.
+func canonicalSettingValue(value interface{}) string {
+	if raw, ok := value.(json.RawMessage); ok {
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return string(raw)
+		}
+		value = v
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// formatSettingValue shows strings without quotes and everything else as
+// compact JSON.
+func formatSettingValue(value interface{}) string {
+	s := canonicalSettingValue(value)
+	if unquoted, err := strconv.Unquote(s); err == nil && strings.HasPrefix(s, `"`) {
+		return unquoted
+	}
+	return s
+}
+
+func readZoneSettingsBaseline(c *cobra.Command) (map[string]interface{}, error) {
+	file, _ := c.Flags().GetString("file")
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline map[string]interface{}
+	if err := json.Unmarshal(b, &baseline); err != nil {
+		return nil, fmt.Errorf("Error reading baseline %s: %w", file, err)
+	}
+	for id, v := range baseline {
+		if baseline[id], err = normalizeZoneSettingValue(id, v); err != nil {
+			return nil, err
+		}
+	}
+	return baseline, nil
+}
+
+// zoneSettingChange is a setting whose value differs from the baseline.
+type zoneSettingChange struct {
+	ID       string      `json:"id"`
+	Current  interface{} `json:"current"`
+	Baseline interface{} `json:"baseline"`
+}
+
+// diffZoneSettings compares the settings of a zone with a baseline. Settings
+// the zone does not have are reported with a nil current value.
+func diffZoneSettings(settings []zoneSetting, baseline map[string]interface{}) []zoneSettingChange {
+	current := make(map[string]json.RawMessage, len(settings))
+	for _, s := range settings {
+		current[s.ID] = s.Value
+	}
+
+	ids := make([]string, 0, len(baseline))
+	for id := range baseline {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	changes := make([]zoneSettingChange, 0)
+	for _, id := range ids {
+		raw, ok := current[id]
+		if !ok {
+			changes = append(changes, zoneSettingChange{ID: id, Baseline: baseline[id]})
+			continue
+		}
+		if canonicalSettingValue(raw) != canonicalSettingValue(baseline[id]) {
+			changes = append(changes, zoneSettingChange{ID: id, Current: raw, Baseline: baseline[id]})
+		}
+	}
+	return changes
+}
+
+func editZoneSetting(c *cobra.Command, zoneID, id string, value interface{}) (*zones.SettingEditResponse, error) {
+	res, err := client.Zones.Settings.Edit(c.Context(), id, zones.SettingEditParams{
+		ZoneID: cloudflare.F(zoneID),
+		Body: zones.SettingEditParamsBody{
+			Value: cloudflare.F(value),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error updating zone setting %s: %w", id, err)
+	}
+	return res, nil
+}
+
+func formatZoneSetting(s zoneSetting) []string {
+	value := formatSettingValue(s.Value)
+	if s.TimeRemaining != nil && *s.TimeRemaining > 0 {
+		value = fmt.Sprintf("%s (%ds remaining)", value, int(*s.TimeRemaining))
+	}
+	return []string{
+		s.ID,
+		value,
+		formatBool(s.Editable),
+		s.ModifiedOn,
+	}
+}
+
+func writeZoneSettings(c *cobra.Command, settings []zoneSetting) error {
+	if jsonOutput(c) {
+		return writeJSON(settings)
+	}
+
+	output := make([][]string, 0, len(settings))
+	for _, s := range settings {
+		output = append(output, formatZoneSetting(s))
+	}
+	writeTable(output, "Setting", "Value", "Editable", "Modified On")
+	return nil
+}
+
+// zoneSettingFromRaw decodes the raw JSON of a single setting response.
+func zoneSettingFromRaw(raw string) (zoneSetting, error) {
+	var s zoneSetting
+	err := json.Unmarshal([]byte(raw), &s)
+	return s, err
+}
+
+func zoneSettingsList(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	settings, err := getZoneSettings(c, zoneID)
+	if err != nil {
+		return err
+	}
+	return writeZoneSettings(c, settings)
+}
+
+func zoneSettingsGet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "setting"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("setting")
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Zones.Settings.Get(c.Context(), id, zones.SettingGetParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting zone setting %s: %w", id, err)
+	}
+
+	s, err := zoneSettingFromRaw(res.JSON.RawJSON())
+	if err != nil {
+		return err
+	}
+	return writeZoneSettings(c, []zoneSetting{s})
+}
+
+func zoneSettingsSet(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "setting", "value"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	id, _ := c.Flags().GetString("setting")
+	raw, _ := c.Flags().GetString("value")
+
+	value, err := parseZoneSettingValue(id, raw)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+
+	res, err := editZoneSetting(c, zoneID, id, value)
+	if err != nil {
+		return err
+	}
+
+	s, err := zoneSettingFromRaw(res.JSON.RawJSON())
+	if err != nil {
+		return err
+	}
+	return writeZoneSettings(c, []zoneSetting{s})
+}
+
+func zoneSettingsDiff(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone", "file"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+
+	baseline, err := readZoneSettingsBaseline(c)
+	if err != nil {
+		return err
+	}
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	settings, err := getZoneSettings(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	changes := diffZoneSettings(settings, baseline)
+	if jsonOutput(c) {
+		return writeJSON(changes)
+	}
+
+	output := make([][]string, 0, len(changes))
+	for _, ch := range changes {
+		current := "(missing)"
+		if ch.Current != nil {
+			current = formatSettingValue(ch.Current)
+		}
+		output = append(output, []string{ch.ID, current, formatSettingValue(ch.Baseline)})
+	}
+	writeTable(output, "Setting", "Current", "Baseline")
+	return nil
+}
+
+func zoneSettingsExport(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "zone"); err != nil {
+		return err
+	}
+	zoneName, _ := c.Flags().GetString("zone")
+	file, _ := c.Flags().GetString("file")
+
+	zoneID, err := getZoneIDByName(c, zoneName)
+	if err != nil {
+		return err
+	}
+	settings, err := getZoneSettings(c, zoneID)
+	if err != nil {
+		return err
+	}
+
+	baseline := make(map[string]json.RawMessage)
+	for _, s := range settings {
+		if s.Editable {
+			baseline[s.ID] = s.Value
+		}
+	}
+
+	b, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		fmt.Println(string(b))
+		return nil
+	}
+	return os.WriteFile(file, append(b, '\n'), 0644)
+}
+
+func zoneSettingsApply(c *cobra.Command, args []string) error {
+	if err := checkFlags(c, "file"); err != nil {
+		return err
+	}
+	zoneNames, _ := c.Flags().GetStringArray("zone")
+	dryRun, _ := c.Flags().GetBool("dry-run")
+	if len(zoneNames) == 0 {
+		return fmt.Errorf("error: the required flag %q was empty or not provided", "zone")
+	}
+
+	baseline, err := readZoneSettingsBaseline(c)
+	if err != nil {
+		return err
+	}
+
+	output := make([][]string, 0)
+	failed := 0
+	for _, zoneName := range zoneNames {
+		zoneID, err := getZoneIDByName(c, zoneName)
+		if err != nil {
+			output = append(output, []string{zoneName, "", "", "", err.Error()})
+			failed++
+			continue
+		}
+		settings, err := getZoneSettings(c, zoneID)
+		if err != nil {
+			output = append(output, []string{zoneName, "", "", "", err.Error()})
+			failed++
+			continue
+		}
+
+		for _, ch := range diffZoneSettings(settings, baseline) {
+			current := "(missing)"
+			if ch.Current != nil {
+				current = formatSettingValue(ch.Current)
+			}
+			result := "would change"
+			if !dryRun {
+				result = "updated"
+				if _, err := editZoneSetting(c, zoneID, ch.ID, ch.Baseline); err != nil {
+					result = err.Error()
+					failed++
+				}
+			}
+			output = append(output, []string{zoneName, ch.ID, current, formatSettingValue(ch.Baseline), result})
+		}
+	}
+
+	writeTable(output, "Zone", "Setting", "Current", "Baseline", "Result")
+	if failed > 0 {
+		return fmt.Errorf("%d zone setting update(s) failed", failed)
+	}
+	return nil
+}
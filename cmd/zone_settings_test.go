@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+func TestParseZoneSettingValue(t *testing.T) {
+	tests := []struct {
+		id, value string
+		want      interface{}
+	}{
+		{"always_use_https", "true", "on"},
+		{"brotli", "OFF", "off"},
+		{"ssl", "Strict", "strict"},
+		{"min_tls_version", "1.2", "1.2"},
+		{"browser_cache_ttl", "14400", 14400},
+		{"ciphers", "ECDHE-RSA-AES128-GCM-SHA256, AES128-SHA", []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"}},
+		{"minify", `{"css":"on","js":"off"}`, map[string]interface{}{"css": "on", "js": "off"}},
+		{"unknown_setting", "value", "value"},
+	}
+	for _, tt := range tests {
+		got, err := parseZoneSettingValue(tt.id, tt.value)
+		if err != nil {
+			t.Errorf("parseZoneSettingValue(%q, %q) error: %v", tt.id, tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseZoneSettingValue(%q, %q) = %#v; want %#v", tt.id, tt.value, got, tt.want)
+		}
+	}
+
+	invalid := [][2]string{
+		{"http3", "maybe"},
+		{"ssl", "full_strict"},
+		{"min_tls_version", "1.4"},
+		{"max_upload", "-1"},
+		{"minify", "on"},
+	}
+	for _, tt := range invalid {
+		if _, err := parseZoneSettingValue(tt[0], tt[1]); err == nil {
+			t.Errorf("parseZoneSettingValue(%q, %q) = nil error; want error", tt[0], tt[1])
+		}
+	}
+}
+
+func TestDiffZoneSettings(t *testing.T) {
+	settings := []zoneSetting{
+		{ID: "ssl", Value: json.RawMessage(`"full"`)},
+		{ID: "browser_cache_ttl", Value: json.RawMessage(`14400`)},
+		{ID: "minify", Value: json.RawMessage(`{"js":"off","css":"on"}`)},
+	}
+	baseline := map[string]interface{}{
+		"ssl":               "strict",
+		"browser_cache_ttl": 14400,
+		"minify":            map[string]interface{}{"css": "on", "js": "off"},
+		"http3":             "on",
+	}
+
+	changes := diffZoneSettings(settings, baseline)
+	var ids []string
+	for _, ch := range changes {
+		ids = append(ids, ch.ID)
+	}
+	if want := []string{"http3", "ssl"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("diffZoneSettings() changed %v; want %v", ids, want)
+	}
+	if changes[0].Current != nil {
+		t.Errorf("missing setting http3 has current value %v; want nil", changes[0].Current)
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v6/dns"
+	"github.com/goccy/go-json"
+	"github.com/spf13/cobra"
+)
+
+func TestScanAcceptFromRecord(t *testing.T) {
+	var r dns.RecordResponse
+	raw := `{"id":"abc","name":"example.com","type":"MX","content":"mx.example.com","priority":0,"ttl":3600,"proxied":false}`
+	if err := json.Unmarshal([]byte(raw), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(scanAcceptFromRecord(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"name":     "example.com",
+		"type":     "MX",
+		"content":  "mx.example.com",
+		"priority": float64(0),
+		"ttl":      float64(3600),
+		"proxied":  false,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("accept[%q] = %v; want %v", k, got[k], v)
+		}
+	}
+	if _, ok := got["data"]; ok {
+		t.Errorf("accept has data %v; want none", got["data"])
+	}
+}
+
+func TestGetZoneColumns(t *testing.T) {
+	cols, err := getZoneColumns("name, Paused,registrar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var headers []string
+	for _, col := range cols {
+		headers = append(headers, col.header)
+	}
+	if want := []string{"Name", "Paused", "Original Registrar"}; !reflect.DeepEqual(headers, want) {
+		t.Errorf("getZoneColumns() headers = %v; want %v", headers, want)
+	}
+
+	for _, columns := range []string{"", "name,bogus"} {
+		if _, err := getZoneColumns(columns); err == nil {
+			t.Errorf("getZoneColumns(%q) = nil error; want error", columns)
+		}
+	}
+}
+
+func TestZoneListParams(t *testing.T) {
+	c := &cobra.Command{}
+	c.Flags().String("status", "active", "")
+	c.Flags().String("name-contains", "shop", "")
+	c.Flags().String("account", "0123456789abcdef0123456789abcdef", "")
+	c.Flags().String("sort", "-name", "")
+
+	params, err := zoneListParams(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := params.URLQuery().Encode(); got != "account.id=0123456789abcdef0123456789abcdef&direction=desc&name=contains%3Ashop&order=name&status=active" {
+		t.Errorf("zoneListParams() query = %s", got)
+	}
+
+	_ = c.Flags().Set("sort", "created")
+	if _, err := zoneListParams(c); err == nil {
+		t.Error("zoneListParams() with invalid sort = nil error; want error")
+	}
+}
+
+func TestParseHoldAfter(t *testing.T) {
+	tests := map[string]string{
+		"2026-12-01":                "2026-12-01T00:00:00Z",
+		"2026-12-01T10:30:00+08:00": "2026-12-01T10:30:00+08:00",
+	}
+	for in, want := range tests {
+		got, err := parseHoldAfter(in)
+		if err != nil || got != want {
+			t.Errorf("parseHoldAfter(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseHoldAfter("01/12/2026"); err == nil {
+		t.Error("parseHoldAfter(\"01/12/2026\") = nil error; want error")
+	}
+}